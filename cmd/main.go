@@ -1,31 +1,44 @@
 package main
 
 import (
+	"bookings/internal/app"
 	"bookings/internal/config"
 	"bookings/internal/logger"
-	"bookings/internal/router"
-	"bookings/internal/storage"
+	"bookings/internal/migrations"
+	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	cfg := config.MustLoad()
+	if err := run(); err != nil {
+		slog.Error("application stopped", logger.Err(err))
+		os.Exit(1)
+	}
+}
 
-	logger.SetupLogger()
+func run() error {
+	cfg := config.MustLoad(os.Args[1:])
 
-	slog.Info("application started")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	postgres, err := storage.NewPostgresDb(cfg)
-	if err != nil {
-		slog.Error("failed to init storage", slog.Attr{Key: "error", Value: slog.StringValue(err.Error())})
-		os.Exit(1)
+	if len(cfg.Args) > 0 {
+		if cfg.Args[0] != "migrate" {
+			return fmt.Errorf("unknown command %q, want migrate or no command", cfg.Args[0])
+		}
+		return migrations.Command(ctx, cfg.DatabaseUrl, cfg.Args[1:], os.Stdin, os.Stdout)
 	}
-	slog.Info("DB connected!")
 
-	_ = postgres
+	a, err := app.New(cfg)
+	if err != nil {
+		return err
+	}
 
-	router := router.SetupRouter()
-	router.Run(":8080")
+	slog.Info("application started")
 
+	return a.Run(ctx)
 }
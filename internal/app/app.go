@@ -0,0 +1,260 @@
+// Package app wires the service together once: logger, storage, router and
+// HTTP server, and tears them down in order on shutdown.
+package app
+
+import (
+	"bookings/internal/config"
+	"bookings/internal/health"
+	"bookings/internal/holds"
+	"bookings/internal/logger"
+	"bookings/internal/migrations"
+	"bookings/internal/pii"
+	"bookings/internal/router"
+	"bookings/internal/storage"
+	"bookings/internal/tracing"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Worker is a background job. It must return once ctx is cancelled.
+type Worker func(ctx context.Context)
+
+type App struct {
+	cfg     *config.Config
+	log     *slog.Logger
+	store   interface{ Close() }
+	tracing func(context.Context) error
+	server  *http.Server
+	health  *health.Checker
+	workers map[string]Worker
+	running map[string]*atomic.Bool
+}
+
+// New builds every dependency from cfg. The returned App owns the database
+// pool; it is closed when Run returns.
+func New(cfg *config.Config) (*App, error) {
+	const op = "app.New"
+
+	pii.SetReveal(cfg.LogPII)
+	logger.SetupLogger(cfg.LogLevel, cfg.LogFormat)
+	log := slog.Default()
+	if cfg.LogPII {
+		log.Warn("personal data is logged unmasked, LOG_PII must not be set in production")
+	}
+
+	// Gin's debug output bypasses slog; keep it for debug logging only.
+	if cfg.LogLevel != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingExporter, cfg.TracingEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := migrate(cfg, log); err != nil {
+		shutdownTracing(context.Background())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	postgres, err := storage.NewPostgresDb(cfg)
+	if err != nil {
+		shutdownTracing(context.Background())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("DB connected!")
+
+	if err := prometheus.Register(postgres.Collector()); err != nil {
+		postgres.Close()
+		shutdownTracing(context.Background())
+		return nil, fmt.Errorf("%s: register metrics: %w", op, err)
+	}
+
+	checker := health.NewChecker()
+	checker.Add("postgres", postgres.Ping)
+	checker.Add("migrations", func(ctx context.Context) error {
+		want, err := migrations.Latest()
+		if err != nil {
+			return err
+		}
+		got, err := postgres.MigrationVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return fmt.Errorf("database is at version %d, expected %d", got, want)
+		}
+		return nil
+	})
+
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.HTTPPort),
+		Handler:      router.SetupRouter(log, postgres, checker),
+		ReadTimeout:  cfg.HTTPReadTimeout,
+		WriteTimeout: cfg.HTTPWriteTimeout,
+		IdleTimeout:  cfg.HTTPIdleTimeout,
+	}
+
+	a := &App{
+		cfg:     cfg,
+		log:     log,
+		store:   postgres,
+		tracing: shutdownTracing,
+		server:  server,
+		health:  checker,
+		workers: make(map[string]Worker),
+		running: make(map[string]*atomic.Bool),
+	}
+	a.AddWorker("hold-sweeper", holds.Sweeper(log, postgres, cfg.HoldSweepInterval))
+
+	return a, nil
+}
+
+// migrationTimeout bounds the migrations applied on startup.
+const migrationTimeout = 5 * time.Minute
+
+// migrate applies pending migrations when MIGRATE_ON_START is set and
+// otherwise refuses to start against an outdated schema.
+func migrate(cfg *config.Config, log *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), migrationTimeout)
+	defer cancel()
+
+	db, err := migrations.Open(cfg.DatabaseUrl)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if cfg.MigrateOnStart {
+		log.Info("applying migrations")
+		return migrations.Up(ctx, db)
+	}
+
+	pending, err := migrations.Pending(ctx, db)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d migrations pending, run `bookings migrate up` or set MIGRATE_ON_START", pending)
+	}
+
+	return nil
+}
+
+// AddWorker registers a background job started by Run and stopped on
+// shutdown after the HTTP server has drained. Readiness fails if the job
+// returns early. It must be called before Run.
+func (a *App) AddWorker(name string, w Worker) {
+	running := new(atomic.Bool)
+	a.workers[name] = w
+	a.running[name] = running
+
+	a.health.Add("worker:"+name, func(ctx context.Context) error {
+		if !running.Load() {
+			return errors.New("not running")
+		}
+		return nil
+	})
+}
+
+// Run serves HTTP until ctx is cancelled or the server fails, then shuts
+// down gracefully: it fails readiness for ShutdownDelay so the instance is
+// taken out of rotation, stops accepting connections, waits up to
+// ShutdownTimeout for in-flight requests, stops the workers, closes the
+// database pool and flushes pending spans.
+func (a *App) Run(ctx context.Context) error {
+	const op = "app.Run"
+
+	ln, err := net.Listen("tcp", a.server.Addr)
+	if err != nil {
+		a.store.Close()
+		if a.tracing != nil {
+			a.tracing(context.Background())
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return a.serve(ctx, ln)
+}
+
+func (a *App) serve(ctx context.Context, ln net.Listener) error {
+	const op = "app.Run"
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for name, w := range a.workers {
+		running := a.running[name]
+		running.Store(true)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			defer running.Store(false)
+			a.log.Info("worker started", slog.String("worker", name))
+			w(workerCtx)
+			a.log.Info("worker stopped", slog.String("worker", name))
+		}()
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		a.log.Info("http server listening", slog.String("addr", ln.Addr().String()))
+		serveErr <- a.server.Serve(ln)
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		a.log.Info("shutdown started")
+	case err := <-serveErr:
+		runErr = fmt.Errorf("%s: http server failed: %w", op, err)
+	}
+
+	a.health.SetDraining()
+	if runErr == nil && a.cfg.ShutdownDelay > 0 {
+		time.Sleep(a.cfg.ShutdownDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.server.Shutdown(shutdownCtx); err != nil {
+		a.log.Warn("http server did not drain in time, closing connections", logger.Err(err))
+		a.server.Close()
+	}
+
+	stopWorkers()
+	drained := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-shutdownCtx.Done():
+		a.log.Warn("workers did not stop in time")
+	}
+
+	a.store.Close()
+
+	if a.tracing != nil {
+		if err := a.tracing(shutdownCtx); err != nil {
+			a.log.Warn("failed to flush traces", logger.Err(err))
+		}
+	}
+	a.log.Info("shutdown finished")
+
+	if runErr != nil && !errors.Is(runErr, http.ErrServerClosed) {
+		return runErr
+	}
+	return nil
+}
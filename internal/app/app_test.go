@@ -0,0 +1,101 @@
+package app
+
+import (
+	"bookings/internal/config"
+	"bookings/internal/health"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeStore struct{ closed atomic.Bool }
+
+func (s *fakeStore) Close() { s.closed.Store(true) }
+
+func TestShutdownDrainsInFlightRequests(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "done")
+	})
+
+	store := &fakeStore{}
+	var workerStopped atomic.Bool
+	a := &App{
+		cfg:     &config.Config{ShutdownTimeout: 5 * time.Second},
+		log:     slog.New(slog.DiscardHandler),
+		store:   store,
+		server:  &http.Server{Handler: handler},
+		health:  health.NewChecker(),
+		workers: make(map[string]Worker),
+		running: make(map[string]*atomic.Bool),
+	}
+	a.AddWorker("test", func(ctx context.Context) {
+		<-ctx.Done()
+		workerStopped.Store(true)
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- a.serve(ctx, ln) }()
+
+	type result struct {
+		body string
+		err  error
+	}
+	resp := make(chan result, 1)
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			resp <- result{err: err}
+			return
+		}
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		resp <- result{string(body), err}
+	}()
+
+	<-started
+	if report := a.health.Run(context.Background()); report.Status != health.StatusOK {
+		t.Errorf("readiness before shutdown = %+v, want ok", report)
+	}
+	cancel()
+
+	// The listener closes before the in-flight request is allowed to finish.
+	time.Sleep(50 * time.Millisecond)
+	if _, err := net.DialTimeout("tcp", ln.Addr().String(), time.Second); err == nil {
+		t.Error("server still accepts connections during shutdown")
+	}
+	if store.closed.Load() {
+		t.Error("store closed before in-flight request finished")
+	}
+	if report := a.health.Run(context.Background()); report.Status != health.StatusDraining {
+		t.Errorf("readiness while draining = %q, want %q", report.Status, health.StatusDraining)
+	}
+
+	close(release)
+
+	if r := <-resp; r.err != nil || r.body != "done" {
+		t.Errorf("in-flight request = %q, %v; want done", r.body, r.err)
+	}
+	if err := <-runErr; err != nil {
+		t.Errorf("serve returned %v", err)
+	}
+	if !workerStopped.Load() {
+		t.Error("worker was not stopped")
+	}
+	if !store.closed.Load() {
+		t.Error("store was not closed")
+	}
+}
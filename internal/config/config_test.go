@@ -0,0 +1,128 @@
+package config_test
+
+import (
+	"bookings/internal/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func lookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := config.Load(nil, lookup(nil))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.HTTPPort != 8080 || cfg.DatabaseMaxConns != 10 || cfg.DatabaseMaxConnLifetime != time.Hour ||
+		cfg.LogLevel != "info" || cfg.LogFormat != "text" || !cfg.MigrateOnStart {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	yamlPath := writeFile(t, "bookings.yaml", "http_port: 9000\nlog_level: debug\nhttp_read_timeout: 5s\ndb_max_conns: 20\n")
+	tomlPath := writeFile(t, "bookings.toml", "http_port = 9000\nlog_level = \"debug\"\nhttp_read_timeout = \"5s\"\ndb_max_conns = 20\n")
+
+	for _, path := range []string{yamlPath, tomlPath} {
+		t.Run(filepath.Ext(path), func(t *testing.T) {
+			env := map[string]string{"CONFIG_PATH": path, "LOG_LEVEL": "warn", "DB_MAX_CONNS": "30"}
+
+			cfg, err := config.Load([]string{"--db-max-conns", "40", "--migrate-on-start=false", "migrate", "status"}, lookup(env))
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			// file over default
+			if cfg.HTTPPort != 9000 || cfg.HTTPReadTimeout != 5*time.Second {
+				t.Errorf("file not applied: port %d, read timeout %s", cfg.HTTPPort, cfg.HTTPReadTimeout)
+			}
+			// env over file
+			if cfg.LogLevel != "warn" {
+				t.Errorf("LogLevel = %q, want warn", cfg.LogLevel)
+			}
+			// flag over env
+			if cfg.DatabaseMaxConns != 40 || cfg.MigrateOnStart {
+				t.Errorf("flags not applied: max conns %d, migrate on start %v", cfg.DatabaseMaxConns, cfg.MigrateOnStart)
+			}
+			// positional arguments after the flags
+			if strings.Join(cfg.Args, " ") != "migrate status" {
+				t.Errorf("Args = %q, want migrate status", cfg.Args)
+			}
+			// untouched default
+			if cfg.HTTPWriteTimeout != 30*time.Second {
+				t.Errorf("HTTPWriteTimeout = %s, want 30s", cfg.HTTPWriteTimeout)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFlagOverridesEnvPath(t *testing.T) {
+	path := writeFile(t, "bookings.yml", "http_port: 9100\n")
+
+	cfg, err := config.Load([]string{"--config", path}, lookup(map[string]string{"CONFIG_PATH": "missing.yaml"}))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.HTTPPort != 9100 {
+		t.Errorf("HTTPPort = %d, want 9100", cfg.HTTPPort)
+	}
+}
+
+func TestLoadReportsAllProblems(t *testing.T) {
+	path := writeFile(t, "bookings.yaml", "http_prot: 9000\n")
+
+	env := map[string]string{
+		"DB_URL":              "mysql://localhost/bookings",
+		"DB_MIN_CONNS":        "50",
+		"HTTP_READ_TIMEOUT":   "soon",
+		"HOLD_SWEEP_INTERVAL": "-1m",
+		"LOG_FORMAT":          "xml",
+	}
+	_, err := config.Load([]string{"--config", path, "--http-port", "70000", "--shutdown-timeout", "0s"}, lookup(env))
+	if err == nil {
+		t.Fatal("Load succeeded, want error")
+	}
+
+	for _, want := range []string{
+		`unknown key "http_prot"`,
+		"DB_URL",
+		"DB_MIN_CONNS (50) must not exceed DB_MAX_CONNS (10)",
+		`env HTTP_READ_TIMEOUT: "soon" is not a duration`,
+		"LOG_FORMAT",
+		"HTTP_PORT must be between 1 and 65535",
+		"SHUTDOWN_TIMEOUT must be positive",
+		"HOLD_SWEEP_INTERVAL must be positive",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadUnsupportedFile(t *testing.T) {
+	path := writeFile(t, "bookings.json", "{}")
+
+	_, err := config.Load([]string{"--config", path}, lookup(nil))
+	if err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Errorf("err = %v, want unsupported format", err)
+	}
+}
@@ -0,0 +1,47 @@
+package amenityHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateAmenity interface {
+	CreateAmenity(ctx context.Context, a models.Amenity) (models.Amenity, error)
+}
+
+func PostAmenityHandler(log *slog.Logger, createAmenity CreateAmenity) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.PostAmenityHandler"
+		var amenity models.Amenity
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		if err := bind.JSON(c, &amenity); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		created, err := createAmenity.CreateAmenity(ctx, amenity)
+		if err != nil {
+			log.InfoContext(ctx, "failed to create amenity", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "amenity created", slog.String("code", created.Code))
+
+		c.JSON(http.StatusCreated, created)
+	}
+}
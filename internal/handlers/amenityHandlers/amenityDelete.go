@@ -0,0 +1,38 @@
+package amenityHandlers
+
+import (
+	"bookings/internal/logger"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteAmenity interface {
+	DeleteAmenity(ctx context.Context, code string) error
+}
+
+func DeleteAmenityHandler(log *slog.Logger, deleteAmenity DeleteAmenity) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.DeleteAmenityHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		code := c.Param("code")
+
+		err := deleteAmenity.DeleteAmenity(ctx, code)
+		if err != nil {
+			log.InfoContext(ctx, "failed to delete amenity", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "amenity deleted", slog.String("code", code))
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,35 @@
+package amenityHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetAmenity interface {
+	GetAmenity(ctx context.Context, code string) (models.Amenity, error)
+}
+
+func GetAmenityHandler(log *slog.Logger, getAmenity GetAmenity) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.GetAmenityHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		amenity, err := getAmenity.GetAmenity(ctx, c.Param("code"))
+		if err != nil {
+			log.InfoContext(ctx, "failed to get amenity", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, amenity)
+	}
+}
@@ -0,0 +1,48 @@
+package amenityHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListAmenities interface {
+	ListAmenities(ctx context.Context, filter storage.AmenityFilter) ([]models.Amenity, error)
+}
+
+// GetAllAmenitiesHandler returns the whole catalog, which is small enough
+// not to be paged.
+func GetAllAmenitiesHandler(log *slog.Logger, listAmenities ListAmenities) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.GetAllAmenitiesHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		var filter storage.AmenityFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		amenities, err := listAmenities.ListAmenities(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get amenities", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, amenities)
+	}
+}
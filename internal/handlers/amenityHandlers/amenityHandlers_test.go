@@ -0,0 +1,148 @@
+package amenityHandlers_test
+
+import (
+	"bookings/internal/handlers/amenityHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setup(t *testing.T) (*gin.Engine, *memory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.POST("/amenities/", amenityHandlers.PostAmenityHandler(log, repo))
+	r.GET("/amenities/", amenityHandlers.GetAllAmenitiesHandler(log, repo))
+	r.GET("/amenities/:code", amenityHandlers.GetAmenityHandler(log, repo))
+	r.PUT("/amenities/:code", amenityHandlers.PutAmenityHandler(log, repo))
+	r.DELETE("/amenities/:code", amenityHandlers.DeleteAmenityHandler(log, repo))
+	r.GET("/hotel/:id/amenities", amenityHandlers.GetHotelAmenitiesHandler(log, repo))
+	r.PUT("/hotel/:id/amenities", amenityHandlers.PutHotelAmenitiesHandler(log, repo))
+	r.GET("/room-types/:roomTypeId/amenities", amenityHandlers.GetRoomTypeAmenitiesHandler(log, repo))
+	r.PUT("/room-types/:roomTypeId/amenities", amenityHandlers.PutRoomTypeAmenitiesHandler(log, repo))
+
+	return r, repo
+}
+
+func perform(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAmenityCatalog(t *testing.T) {
+	r, _ := setup(t)
+
+	body := `{"code":"wifi","category":"connectivity","labels":{"en":"Wi-Fi","de":"WLAN"}}`
+
+	w := perform(r, http.MethodPost, "/amenities/", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var amenity models.Amenity
+	if err := json.Unmarshal(w.Body.Bytes(), &amenity); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if amenity.Code != "wifi" || amenity.Labels["de"] != "WLAN" {
+		t.Fatalf("created amenity = %+v", amenity)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"same code", http.MethodPost, "/amenities/", body, http.StatusConflict},
+		{"invalid code", http.MethodPost, "/amenities/", `{"code":"Wi Fi","category":"connectivity"}`, http.StatusBadRequest},
+		{"missing category", http.MethodPost, "/amenities/", `{"code":"pool"}`, http.StatusBadRequest},
+		{"invalid locale", http.MethodPost, "/amenities/", `{"code":"pool","category":"wellness","labels":{"x":"Pool"}}`, http.StatusBadRequest},
+		{"get", http.MethodGet, "/amenities/wifi", "", http.StatusOK},
+		{"get missing", http.MethodGet, "/amenities/sauna", "", http.StatusNotFound},
+		{"update", http.MethodPut, "/amenities/wifi", `{"category":"internet","labels":{"en":"Wireless"}}`, http.StatusOK},
+		{"update other code", http.MethodPut, "/amenities/wifi", `{"code":"wlan","category":"internet"}`, http.StatusBadRequest},
+		{"update missing", http.MethodPut, "/amenities/sauna", `{"category":"wellness"}`, http.StatusNotFound},
+		{"delete", http.MethodDelete, "/amenities/wifi", "", http.StatusNoContent},
+		{"delete again", http.MethodDelete, "/amenities/wifi", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestOfferedAmenities(t *testing.T) {
+	r, repo := setup(t)
+
+	storagetest.MustCreateAmenity(t, repo, "wifi", "connectivity")
+	storagetest.MustCreateAmenity(t, repo, "meals", "food")
+	hotel := storagetest.MustCreateHotel(t, repo, "Portugal", "Lisbon", "Pestana", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+
+	hotelPath := "/hotel/" + strconv.Itoa(hotel.Id) + "/amenities"
+	typePath := "/room-types/" + strconv.Itoa(roomType.Id) + "/amenities"
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"set hotel", http.MethodPut, hotelPath, `{"amenities":["wifi"]}`, http.StatusOK},
+		{"set room type", http.MethodPut, typePath, `{"amenities":["meals","wifi"]}`, http.StatusOK},
+		{"unknown amenity", http.MethodPut, hotelPath, `{"amenities":["sauna"]}`, http.StatusNotFound},
+		{"missing list", http.MethodPut, hotelPath, `{}`, http.StatusBadRequest},
+		{"missing hotel", http.MethodPut, "/hotel/999/amenities", `{"amenities":[]}`, http.StatusNotFound},
+		{"missing room type", http.MethodGet, "/room-types/999/amenities", "", http.StatusNotFound},
+		{"invalid hotel id", http.MethodGet, "/hotel/abc/amenities", "", http.StatusBadRequest},
+		{"in use", http.MethodDelete, "/amenities/meals", "", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	for path, want := range map[string]int{hotelPath: 1, typePath: 2} {
+		w := perform(r, http.MethodGet, path, "")
+
+		var amenities []models.Amenity
+		if err := json.Unmarshal(w.Body.Bytes(), &amenities); err != nil {
+			t.Fatalf("GET %s: invalid body %s: %v", path, w.Body, err)
+		}
+		if len(amenities) != want {
+			t.Fatalf("GET %s = %+v, want %d amenities", path, amenities, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package amenityHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateAmenity interface {
+	UpdateAmenity(ctx context.Context, code string, a models.Amenity) (models.Amenity, error)
+}
+
+// PutAmenityHandler replaces the category and labels of an amenity. The code
+// in the body must match the path, since codes never change.
+func PutAmenityHandler(log *slog.Logger, updateAmenity UpdateAmenity) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.PutAmenityHandler"
+		var amenity models.Amenity
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		code := c.Param("code")
+		amenity.Code = code
+
+		if err := bind.JSON(c, &amenity); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		if amenity.Code != code {
+			log.InfoContext(ctx, "amenity code mismatch", slog.String("code", code))
+
+			c.Error(storage.NewError(storage.ErrValidation, "amenity code cannot be changed"))
+
+			return
+		}
+
+		updated, err := updateAmenity.UpdateAmenity(ctx, code, amenity)
+		if err != nil {
+			log.InfoContext(ctx, "failed to update amenity", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "amenity updated", slog.String("code", code))
+
+		c.JSON(http.StatusOK, updated)
+	}
+}
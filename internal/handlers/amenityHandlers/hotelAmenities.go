@@ -0,0 +1,97 @@
+package amenityHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// amenitiesRequest replaces the amenities an owner offers; an empty list
+// removes them all.
+type amenitiesRequest struct {
+	Amenities []string `json:"amenities" binding:"required,dive,required,max=50"`
+}
+
+type ListHotelAmenities interface {
+	ListHotelAmenities(ctx context.Context, hotelId int) ([]models.Amenity, error)
+}
+
+func GetHotelAmenitiesHandler(log *slog.Logger, listHotelAmenities ListHotelAmenities) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.GetHotelAmenitiesHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		hotelId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		amenities, err := listHotelAmenities.ListHotelAmenities(ctx, hotelId)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get hotel amenities", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, amenities)
+	}
+}
+
+type SetHotelAmenities interface {
+	SetHotelAmenities(ctx context.Context, hotelId int, codes []string) ([]models.Amenity, error)
+}
+
+func PutHotelAmenitiesHandler(log *slog.Logger, setHotelAmenities SetHotelAmenities) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.PutHotelAmenitiesHandler"
+		var req amenitiesRequest
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		hotelId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &req); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		amenities, err := setHotelAmenities.SetHotelAmenities(ctx, hotelId, req.Amenities)
+		if err != nil {
+			log.InfoContext(ctx, "failed to set hotel amenities", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "hotel amenities set", slog.Int("hotel_id", hotelId), slog.Int("count", len(amenities)))
+
+		c.JSON(http.StatusOK, amenities)
+	}
+}
@@ -0,0 +1,91 @@
+package amenityHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListRoomTypeAmenities interface {
+	ListRoomTypeAmenities(ctx context.Context, roomTypeId int) ([]models.Amenity, error)
+}
+
+func GetRoomTypeAmenitiesHandler(log *slog.Logger, listRoomTypeAmenities ListRoomTypeAmenities) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.GetRoomTypeAmenitiesHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomTypeId, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		amenities, err := listRoomTypeAmenities.ListRoomTypeAmenities(ctx, roomTypeId)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get room type amenities", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, amenities)
+	}
+}
+
+type SetRoomTypeAmenities interface {
+	SetRoomTypeAmenities(ctx context.Context, roomTypeId int, codes []string) ([]models.Amenity, error)
+}
+
+func PutRoomTypeAmenitiesHandler(log *slog.Logger, setRoomTypeAmenities SetRoomTypeAmenities) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.amenityHandlers.PutRoomTypeAmenitiesHandler"
+		var req amenitiesRequest
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomTypeId, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &req); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		amenities, err := setRoomTypeAmenities.SetRoomTypeAmenities(ctx, roomTypeId, req.Amenities)
+		if err != nil {
+			log.InfoContext(ctx, "failed to set room type amenities", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "room type amenities set", slog.Int("room_type_id", roomTypeId), slog.Int("count", len(amenities)))
+
+		c.JSON(http.StatusOK, amenities)
+	}
+}
@@ -0,0 +1,103 @@
+package availabilityHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchAvailability interface {
+	SearchAvailability(ctx context.Context, filter storage.AvailabilityFilter) ([]models.HotelAvailability, error)
+}
+
+func GetAvailabilityHandler(log *slog.Logger, searchAvailability SearchAvailability) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.availabilityHandlers.GetAvailabilityHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		filter, err := parseAvailabilityFilter(c)
+		if err != nil {
+			log.InfoContext(ctx, "invalid availability query", logger.Err(err))
+
+			var domainErr *storage.Error
+			if !errors.As(err, &domainErr) {
+				err = storage.NewError(storage.ErrValidation, err.Error())
+			}
+			c.Error(err)
+
+			return
+		}
+
+		hotels, err := searchAvailability.SearchAvailability(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to search availability", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, hotels)
+	}
+}
+
+func parseAvailabilityFilter(c *gin.Context) (storage.AvailabilityFilter, error) {
+	filter := storage.AvailabilityFilter{
+		City:      c.Query("city"),
+		Country:   c.Query("country"),
+		Guests:    1,
+		StarsMin:  1,
+		Amenities: storage.AmenityCodes(c.QueryArray("amenities")),
+	}
+
+	var err error
+
+	filter.CheckIn, err = models.ParseDate(c.Query("check_in"))
+	if err != nil {
+		return filter, fmt.Errorf("check_in: %w", err)
+	}
+
+	filter.CheckOut, err = models.ParseDate(c.Query("check_out"))
+	if err != nil {
+		return filter, fmt.Errorf("check_out: %w", err)
+	}
+
+	if !filter.CheckOut.After(filter.CheckIn.Time) {
+		return filter, fmt.Errorf("check_out must be after check_in")
+	}
+
+	if storage.StayTooLong(filter.CheckIn, filter.CheckOut) {
+		return filter, storage.NewFieldError([]storage.FieldError{{
+			Field:   "check_out",
+			Rule:    "max",
+			Param:   strconv.Itoa(storage.MaxStayNights),
+			Message: fmt.Sprintf("must be at most %d nights after check_in", storage.MaxStayNights),
+		}})
+	}
+
+	if guests := c.Query("guests"); guests != "" {
+		filter.Guests, err = strconv.Atoi(guests)
+		if err != nil || filter.Guests < 1 {
+			return filter, fmt.Errorf("guests must be a positive integer")
+		}
+	}
+
+	if starsMin := c.Query("stars_min"); starsMin != "" {
+		filter.StarsMin, err = strconv.Atoi(starsMin)
+		if err != nil || filter.StarsMin < 1 || filter.StarsMin > 5 {
+			return filter, fmt.Errorf("stars_min must be between 1 and 5")
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,108 @@
+package availabilityHandlers_test
+
+import (
+	"bookings/internal/handlers/availabilityHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetAvailability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.GET("/availability", availabilityHandlers.GetAvailabilityHandler(log, repo))
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Germany", "Berlin", "Adlon", 5)
+	double := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	storagetest.MustCreateHotelRoom(t, repo, double)
+	storagetest.MustCreateHotelRoom(t, repo, storagetest.MustCreateRoomType(t, repo, hotel.Id, 1))
+
+	storagetest.MustCreateAmenity(t, repo, "wifi", "connectivity")
+	storagetest.MustCreateAmenity(t, repo, "meals", "food")
+	if _, err := repo.SetRoomTypeAmenities(context.Background(), double.Id, []string{"wifi", "meals"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		want      int
+		roomTypes int
+	}{
+		{"found", "?city=Berlin&check_in=2030-07-01&check_out=2030-07-03&guests=2", http.StatusOK, 1},
+		{"defaults to one guest", "?country=germany&check_in=2030-07-01&check_out=2030-07-03", http.StatusOK, 2},
+		{"with amenities", "?check_in=2030-07-01&check_out=2030-07-03&amenities=wifi,meals", http.StatusOK, 1},
+		{"missing dates", "?city=Berlin", http.StatusBadRequest, 0},
+		{"reversed dates", "?check_in=2030-07-03&check_out=2030-07-01", http.StatusBadRequest, 0},
+		{"longest stay", "?check_in=2030-07-01&check_out=2031-07-01", http.StatusOK, 2},
+		{"stay too long", "?check_in=2030-07-01&check_out=2031-07-02", http.StatusBadRequest, 0},
+		{"bad guests", "?check_in=2030-07-01&check_out=2030-07-03&guests=0", http.StatusBadRequest, 0},
+		{"bad stars", "?check_in=2030-07-01&check_out=2030-07-03&stars_min=6", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/availability"+tt.query, nil))
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var hotels []models.HotelAvailability
+			if err := json.Unmarshal(w.Body.Bytes(), &hotels); err != nil {
+				t.Fatalf("invalid body %s: %v", w.Body, err)
+			}
+			if len(hotels) != 1 || len(hotels[0].RoomTypes) != tt.roomTypes {
+				t.Fatalf("hotels = %+v, want %d room types", hotels, tt.roomTypes)
+			}
+			if got := hotels[0].RoomTypes[0]; got.Id != double.Id || got.Available != 1 {
+				t.Fatalf("first room type = %+v, want one free room of type %d", got, double.Id)
+			}
+		})
+	}
+}
+
+func TestGetAvailabilityStayTooLong(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.GET("/availability", availabilityHandlers.GetAvailabilityHandler(log, memory.New()))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/availability?check_in=2026-01-01&check_out=9999-12-31", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body)
+	}
+
+	var problem middleware.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "check_out" || problem.Errors[0].Param != strconv.Itoa(storage.MaxStayNights) {
+		t.Fatalf("problem = %+v, want a check_out max error", problem)
+	}
+}
@@ -0,0 +1,128 @@
+// Package bind decodes and validates request bodies for all handlers, so
+// every endpoint reports malformed input the same way.
+package bind
+
+import (
+	"bookings/internal/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Report fields under their JSON or query names rather than the Go ones.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// JSON decodes the request body into obj and runs its binding rules. The
+// returned error is a storage.ErrValidation domain error listing every
+// failed field, or nil.
+func JSON(c *gin.Context, obj any) error {
+	return validationErr(c.ShouldBindJSON(obj))
+}
+
+// Query binds the query string into obj using its form tags and runs its
+// binding rules, reporting failures like JSON does.
+func Query(c *gin.Context, obj any) error {
+	return validationErr(c.ShouldBindQuery(obj))
+}
+
+func validationErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return storage.NewError(storage.ErrValidation, "request body is empty")
+	case errors.As(err, &validationErrs):
+		fields := make([]storage.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, fieldError(fe))
+		}
+		return storage.NewFieldError(fields)
+	case errors.As(err, &typeErr):
+		return storage.NewFieldError([]storage.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   typeErr.Type.String(),
+			Message: fmt.Sprintf("must be of type %s", typeErr.Type),
+		}})
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return storage.NewError(storage.ErrValidation, "request body is not valid JSON")
+	}
+
+	return storage.NewError(storage.ErrValidation, err.Error())
+}
+
+func fieldError(fe validator.FieldError) storage.FieldError {
+	return storage.FieldError{
+		Field:   fe.Field(),
+		Rule:    fe.Tag(),
+		Param:   fe.Param(),
+		Message: message(fe),
+	}
+}
+
+// message renders a validation failure for the rules used by the models.
+func message(fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	case "iso3166_1_alpha2":
+		return "must be an ISO 3166-1 alpha-2 country code"
+	case "iso4217":
+		return "must be an ISO 4217 currency code"
+	}
+
+	return fmt.Sprintf("failed the %s rule", fe.Tag())
+}
+
+func fieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		tag = field.Tag.Get("form")
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
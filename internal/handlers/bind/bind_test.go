@@ -0,0 +1,69 @@
+package bind_test
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func bindHotel(t *testing.T, body string) error {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/hotel/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var hotel models.Hotel
+	return bind.JSON(c, &hotel)
+}
+
+func TestJSON(t *testing.T) {
+	if err := bindHotel(t, `{"country":"DE","city":"Berlin","hotel_name":"Adlon","stars":5}`); err != nil {
+		t.Fatalf("valid hotel rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		fields []string
+	}{
+		{"empty body", ``, nil},
+		{"malformed", `{"country":`, nil},
+		{"wrong type", `{"country":"DE","city":"Berlin","hotel_name":"Adlon","stars":"five"}`, []string{"stars"}},
+		{"all fields at once", `{"country":"Germany","stars":9}`, []string{"country", "city", "hotel_name", "stars"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bindHotel(t, tt.body)
+			if !errors.Is(err, storage.ErrValidation) {
+				t.Fatalf("error = %v, want a validation error", err)
+			}
+
+			var domainErr *storage.Error
+			if !errors.As(err, &domainErr) {
+				t.Fatalf("error %v is not a domain error", err)
+			}
+
+			var fields []string
+			for _, f := range domainErr.Fields {
+				if f.Rule == "" || f.Message == "" {
+					t.Fatalf("incomplete field error %+v", f)
+				}
+				fields = append(fields, f.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Fatalf("fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
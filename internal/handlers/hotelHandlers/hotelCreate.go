@@ -1,10 +1,10 @@
 package handlers
 
 import (
+	"bookings/internal/handlers/bind"
 	"bookings/internal/logger"
 	"bookings/internal/models"
-	"errors"
-	"io"
+	"context"
 	"log/slog"
 	"net/http"
 
@@ -12,7 +12,7 @@ import (
 )
 
 type CreateHotel interface {
-	CreateHotel(country string, city string, hotelName string, stars int) error
+	CreateHotel(ctx context.Context, country string, city string, hotelName string, stars int) (models.Hotel, error)
 }
 
 func PostHotelHandler(log *slog.Logger, createHotel CreateHotel) gin.HandlerFunc {
@@ -20,39 +20,28 @@ func PostHotelHandler(log *slog.Logger, createHotel CreateHotel) gin.HandlerFunc
 		const op = "handlers.hotelHandlers.PostHotelHandler"
 		var hotel models.Hotel
 
-		slog := slog.With(
-			slog.String("op", op),
-		)
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
 
-		err := c.ShouldBindJSON(&hotel)
-		if errors.Is(err, io.EOF) {
-			slog.Info("request body is empty")
+		if err := bind.JSON(c, &hotel); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
 
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			c.Error(err)
 
 			return
 		}
 
+		created, err := createHotel.CreateHotel(ctx, hotel.Country, hotel.City, hotel.HotelName, hotel.Stars)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			log.InfoContext(ctx, "failed to create hotel", logger.Err(err))
 
-			slog.Info("failed to decode request body", logger.Err(err))
+			c.Error(err)
 
 			return
 		}
 
-		slog.Info("request body decoded")
+		log.InfoContext(ctx, "hotel created", slog.Int("id", created.Id))
 
-		err = createHotel.CreateHotel(hotel.Country, hotel.City, hotel.HotelName, hotel.Stars)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err})
-
-			slog.Info("failed to create hotel")
-
-			return
-		}
-
-		c.JSON(200, hotel)
-		slog.Info("HOTEL CREATED")
+		c.JSON(http.StatusOK, created)
 	}
 }
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteHotel interface {
+	DeleteHotel(ctx context.Context, id int) error
+}
+
+func DeleteHotelHandler(log *slog.Logger, deleteHotel DeleteHotel) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelHandlers.DeleteHotelHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		err = deleteHotel.DeleteHotel(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to delete hotel", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "hotel deleted", slog.Int("id", id))
+
+		c.Status(http.StatusNoContent)
+	}
+}
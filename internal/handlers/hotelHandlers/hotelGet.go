@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -9,27 +13,34 @@ import (
 )
 
 type GetHotel interface {
-	GetHotel(id int) (string, error)
+	GetHotel(ctx context.Context, id int) (models.Hotel, error)
 }
 
-func GetHotelHandler(log slog.Logger, getHotel GetHotel) gin.HandlerFunc {
+func GetHotelHandler(log *slog.Logger, getHotel GetHotel) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		const op = "handlers.hotelHandlers.GetHotelHandler"
 
-		slog := slog.With(slog.String("op", op))
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
 
-		idStr := c.Param("id")
-		id, _ := strconv.Atoi(idStr)
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
 
-		data, err := getHotel.GetHotel(id)
+		hotel, err := getHotel.GetHotel(ctx, id)
 		if err != nil {
-			slog.Info("failed to get hotel")
+			log.InfoContext(ctx, "failed to get hotel", logger.Err(err))
 
-			c.JSON(http.StatusBadRequest, gin.H{"err": err})
+			c.Error(err)
 
 			return
 		}
 
-		c.JSON(200, data)
+		c.JSON(http.StatusOK, hotel)
 	}
 }
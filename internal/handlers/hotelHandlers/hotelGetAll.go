@@ -1,32 +1,46 @@
 package handlers
 
 import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
 	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
-type GetHotels interface {
-	GetAllHotels() (string, error)
+type ListHotels interface {
+	ListHotels(ctx context.Context, filter storage.HotelFilter) (models.Page[models.Hotel], error)
 }
 
-func GetAllHotelHandler(log *slog.Logger, getHotels GetHotels) gin.HandlerFunc {
+func GetAllHotelHandler(log *slog.Logger, listHotels ListHotels) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		const op = "handlers.hotelHandlers.GetAllHotelHandler"
 
-		slog.With(slog.String("op", op))
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
 
-		data, err := getHotels.GetAllHotels()
+		var filter storage.HotelFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		hotels, err := listHotels.ListHotels(ctx, filter)
 		if err != nil {
-			slog.Info("failed to get hotels")
+			log.InfoContext(ctx, "failed to get hotels", logger.Err(err))
 
-			c.JSON(http.StatusBadRequest, gin.H{"err": err})
+			c.Error(err)
 
 			return
 		}
 
-		c.Data(200, "application/json", []byte(data))
-		slog.Info("succssesful")
+		c.JSON(http.StatusOK, hotels)
 	}
 }
@@ -0,0 +1,219 @@
+package handlers_test
+
+import (
+	handlers "bookings/internal/handlers/hotelHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setup(t *testing.T) (*gin.Engine, *memory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.POST("/hotel/", handlers.PostHotelHandler(log, repo))
+	r.GET("/hotel/", handlers.GetAllHotelHandler(log, repo))
+	r.GET("/hotel/:id", handlers.GetHotelHandler(log, repo))
+	r.PUT("/hotel/:id", handlers.PutHotelHandler(log, repo))
+	r.DELETE("/hotel/:id", handlers.DeleteHotelHandler(log, repo))
+
+	return r, repo
+}
+
+func perform(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestPostAndListHotels(t *testing.T) {
+	r, _ := setup(t)
+
+	w := perform(r, http.MethodPost, "/hotel/", `{"country":"DE","city":"Berlin","hotel_name":"Adlon","stars":5}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var created models.Hotel
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if created.Id == 0 {
+		t.Fatalf("created hotel has no id: %+v", created)
+	}
+
+	w = perform(r, http.MethodPost, "/hotel/", ``)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("POST empty body status = %d", w.Code)
+	}
+
+	w = perform(r, http.MethodGet, "/hotel/", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", w.Code)
+	}
+
+	var hotels models.Page[models.Hotel]
+	if err := json.Unmarshal(w.Body.Bytes(), &hotels); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if len(hotels.Items) != 1 || hotels.Items[0].HotelName != "Adlon" || hotels.NextCursor != "" {
+		t.Fatalf("hotels = %+v", hotels)
+	}
+}
+
+func TestListHotelsPaginated(t *testing.T) {
+	r, repo := setup(t)
+
+	storagetest.MustCreateHotel(t, repo, "DE", "Berlin", "Adlon", 5)
+	storagetest.MustCreateHotel(t, repo, "DE", "Berlin", "Amano", 3)
+	storagetest.MustCreateHotel(t, repo, "FR", "Paris", "Ritz", 5)
+
+	var names []string
+	path := "/hotel/?country=de&stars_min=3&sort=-hotel_name&limit=1"
+	for i := 0; ; i++ {
+		if i > 2 {
+			t.Fatalf("pagination did not end")
+		}
+
+		w := perform(r, http.MethodGet, path, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d, body %s", path, w.Code, w.Body)
+		}
+
+		var page models.Page[models.Hotel]
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("invalid body %s: %v", w.Body, err)
+		}
+		for _, h := range page.Items {
+			names = append(names, h.HotelName)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		path = "/hotel/?country=de&stars_min=3&sort=-hotel_name&limit=1&cursor=" + page.NextCursor
+	}
+
+	if strings.Join(names, ",") != "Amano,Adlon" {
+		t.Fatalf("hotels = %v", names)
+	}
+
+	for _, query := range []string{"sort=password", "limit=1000", "stars_min=4&stars_max=2", "cursor=bogus"} {
+		w := perform(r, http.MethodGet, "/hotel/?"+query, "")
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("GET /hotel/?%s status = %d, want 400", query, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"errors":[`) {
+			t.Fatalf("GET /hotel/?%s body %s has no field errors", query, w.Body)
+		}
+	}
+}
+
+func TestGetHotel(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Germany", "Berlin", "Adlon", 5)
+
+	w := perform(r, http.MethodGet, "/hotel/"+strconv.Itoa(hotel.Id), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d, body %s", w.Code, w.Body)
+	}
+
+	var got models.Hotel
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("body is not a hotel object %s: %v", w.Body, err)
+	}
+	if got != hotel {
+		t.Fatalf("hotel = %+v, want %+v", got, hotel)
+	}
+}
+
+func TestPutHotel(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Germany", "Berlin", "Adlon", 5)
+	storagetest.MustCreateHotel(t, repo, "France", "Paris", "Ritz", 5)
+	path := "/hotel/" + strconv.Itoa(hotel.Id)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"updated", path, `{"country":"DE","city":"Potsdam","hotel_name":"Adlon","stars":4}`, http.StatusOK},
+		{"invalid id", "/hotel/abc", `{}`, http.StatusBadRequest},
+		{"empty body", path, ``, http.StatusBadRequest},
+		{"not found", "/hotel/999", `{"country":"DE","city":"Berlin","hotel_name":"Other","stars":4}`, http.StatusNotFound},
+		{"name taken", path, `{"country":"DE","city":"Berlin","hotel_name":"Ritz","stars":4}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, http.MethodPut, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	w := perform(r, http.MethodPut, path, `{"country":"DE","city":"Potsdam","hotel_name":"Adlon","stars":4}`)
+
+	var got models.Hotel
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if got.Id != hotel.Id || got.City != "Potsdam" || got.Stars != 4 {
+		t.Fatalf("updated hotel = %+v", got)
+	}
+}
+
+func TestDeleteHotel(t *testing.T) {
+	r, repo := setup(t)
+
+	empty := storagetest.MustCreateHotel(t, repo, "Germany", "Berlin", "Adlon", 5)
+	withRooms := storagetest.MustCreateHotel(t, repo, "Germany", "Munich", "Bayerischer Hof", 5)
+	storagetest.MustCreateHotelRoom(t, repo, storagetest.MustCreateRoomType(t, repo, withRooms.Id, 2))
+	withRoomTypes := storagetest.MustCreateHotel(t, repo, "France", "Paris", "Ritz", 5)
+	storagetest.MustCreateRoomType(t, repo, withRoomTypes.Id, 2)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"deleted", "/hotel/" + strconv.Itoa(empty.Id), http.StatusNoContent},
+		{"already deleted", "/hotel/" + strconv.Itoa(empty.Id), http.StatusNotFound},
+		{"has rooms", "/hotel/" + strconv.Itoa(withRooms.Id), http.StatusConflict},
+		{"has room types", "/hotel/" + strconv.Itoa(withRoomTypes.Id), http.StatusConflict},
+		{"invalid id", "/hotel/abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, http.MethodDelete, tt.path, "")
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
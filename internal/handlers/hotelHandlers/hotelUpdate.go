@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateHotel interface {
+	UpdateHotel(ctx context.Context, id int, country string, city string, hotelName string, stars int) (models.Hotel, error)
+}
+
+func PutHotelHandler(log *slog.Logger, updateHotel UpdateHotel) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelHandlers.PutHotelHandler"
+		var hotel models.Hotel
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &hotel); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		updated, err := updateHotel.UpdateHotel(ctx, id, hotel.Country, hotel.City, hotel.HotelName, hotel.Stars)
+		if err != nil {
+			log.InfoContext(ctx, "failed to update hotel", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "hotel updated", slog.Int("id", id))
+
+		c.JSON(http.StatusOK, updated)
+	}
+}
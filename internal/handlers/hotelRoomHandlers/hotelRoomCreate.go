@@ -0,0 +1,58 @@
+package hotelRoomHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateHotelRoom interface {
+	CreateHotelRoom(ctx context.Context, hotelId int, roomTypeId int, roomNumber int, floor int) (models.HotelRoom, error)
+}
+
+func PostHotelRoomHandler(log *slog.Logger, createHotelRoom CreateHotelRoom) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelRoomHandlers.PostHotelRoomHandler"
+		var room models.HotelRoom
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		hotelId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &room); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		created, err := createHotelRoom.CreateHotelRoom(ctx, hotelId, room.RoomTypeId, room.RoomNumber, room.Floor)
+		if err != nil {
+			log.InfoContext(ctx, "failed to create hotel room", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "hotel room created", slog.Int("hotel_id", hotelId))
+
+		c.JSON(http.StatusCreated, created)
+	}
+}
@@ -0,0 +1,47 @@
+package hotelRoomHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteHotelRoom interface {
+	DeleteHotelRoom(ctx context.Context, id int) error
+}
+
+func DeleteHotelRoomHandler(log *slog.Logger, deleteHotelRoom DeleteHotelRoom) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelRoomHandlers.DeleteHotelRoomHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("roomId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room id", slog.String("id", c.Param("roomId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room id"))
+
+			return
+		}
+
+		err = deleteHotelRoom.DeleteHotelRoom(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to delete hotel room", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "hotel room deleted", slog.Int("id", id))
+
+		c.Status(http.StatusNoContent)
+	}
+}
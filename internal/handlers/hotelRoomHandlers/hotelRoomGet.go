@@ -0,0 +1,46 @@
+package hotelRoomHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetHotelRoom interface {
+	GetHotelRoom(ctx context.Context, id int) (models.HotelRoom, error)
+}
+
+func GetHotelRoomHandler(log *slog.Logger, getHotelRoom GetHotelRoom) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelRoomHandlers.GetHotelRoomHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("roomId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room id", slog.String("id", c.Param("roomId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room id"))
+
+			return
+		}
+
+		room, err := getHotelRoom.GetHotelRoom(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get hotel room", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, room)
+	}
+}
@@ -0,0 +1,125 @@
+package hotelRoomHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListHotelRooms interface {
+	ListHotelRooms(ctx context.Context, filter storage.HotelRoomFilter) (models.Page[models.HotelRoom], error)
+}
+
+func GetAllHotelRoomsHandler(log *slog.Logger, listHotelRooms ListHotelRooms) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelRoomHandlers.GetAllHotelRoomsHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		var filter storage.HotelRoomFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		rooms, err := listHotelRooms.ListHotelRooms(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get hotel rooms", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, rooms)
+	}
+}
+
+func GetHotelRoomsByHotelHandler(log *slog.Logger, listHotelRooms ListHotelRooms) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelRoomHandlers.GetHotelRoomsByHotelHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		hotelId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		var filter storage.HotelRoomFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		filter.HotelId = hotelId
+
+		rooms, err := listHotelRooms.ListHotelRooms(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get hotel rooms", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, rooms)
+	}
+}
+
+func GetHotelRoomsByRoomTypeHandler(log *slog.Logger, listHotelRooms ListHotelRooms) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelRoomHandlers.GetHotelRoomsByRoomTypeHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomTypeId, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		var filter storage.HotelRoomFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		filter.RoomTypeId = roomTypeId
+
+		rooms, err := listHotelRooms.ListHotelRooms(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get hotel rooms", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, rooms)
+	}
+}
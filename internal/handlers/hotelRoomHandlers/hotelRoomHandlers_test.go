@@ -0,0 +1,149 @@
+package hotelRoomHandlers_test
+
+import (
+	"bookings/internal/handlers/hotelRoomHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setup(t *testing.T) (*gin.Engine, *memory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.GET("/hotel/:id/rooms", hotelRoomHandlers.GetHotelRoomsByHotelHandler(log, repo))
+	r.POST("/hotel/:id/rooms", hotelRoomHandlers.PostHotelRoomHandler(log, repo))
+	r.GET("/room-types/:roomTypeId/rooms", hotelRoomHandlers.GetHotelRoomsByRoomTypeHandler(log, repo))
+	r.GET("/rooms/", hotelRoomHandlers.GetAllHotelRoomsHandler(log, repo))
+	r.GET("/rooms/:roomId", hotelRoomHandlers.GetHotelRoomHandler(log, repo))
+	r.PUT("/rooms/:roomId", hotelRoomHandlers.PutHotelRoomHandler(log, repo))
+	r.DELETE("/rooms/:roomId", hotelRoomHandlers.DeleteHotelRoomHandler(log, repo))
+
+	return r, repo
+}
+
+func perform(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateAndGetHotelRoom(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Italy", "Rome", "Hassler", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 3)
+	hotelPath := "/hotel/" + strconv.Itoa(hotel.Id) + "/rooms"
+	body := func(roomTypeId, roomNumber int) string {
+		return fmt.Sprintf(`{"room_type_id":%d,"room_number":%d,"floor":2}`, roomTypeId, roomNumber)
+	}
+
+	w := perform(r, http.MethodPost, hotelPath, body(roomType.Id, 201))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var room models.HotelRoom
+	if err := json.Unmarshal(w.Body.Bytes(), &room); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if room.HotelId != hotel.Id || room.RoomTypeId != roomType.Id || room.RoomNumber != 201 || room.Floor != 2 {
+		t.Fatalf("created room = %+v", room)
+	}
+
+	w = perform(r, http.MethodPost, "/hotel/999/rooms", body(roomType.Id, 1))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("POST for missing hotel status = %d", w.Code)
+	}
+
+	w = perform(r, http.MethodPost, hotelPath, body(999, 1))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("POST for missing room type status = %d", w.Code)
+	}
+
+	w = perform(r, http.MethodPost, hotelPath, `{"room_number":1}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("POST without room type status = %d", w.Code)
+	}
+
+	w = perform(r, http.MethodGet, "/rooms/"+strconv.Itoa(room.Id), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", w.Code)
+	}
+
+	w = perform(r, http.MethodGet, "/rooms/999", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("GET missing room status = %d", w.Code)
+	}
+
+	for _, path := range []string{hotelPath, "/room-types/" + strconv.Itoa(roomType.Id) + "/rooms", "/rooms/"} {
+		w = perform(r, http.MethodGet, path, "")
+
+		var rooms models.Page[models.HotelRoom]
+		if err := json.Unmarshal(w.Body.Bytes(), &rooms); err != nil {
+			t.Fatalf("GET %s: invalid body %s: %v", path, w.Body, err)
+		}
+		if len(rooms.Items) != 1 || rooms.Items[0] != room {
+			t.Fatalf("GET %s = %+v", path, rooms)
+		}
+	}
+}
+
+func TestUpdateAndDeleteHotelRoom(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Italy", "Rome", "Hassler", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := storagetest.MustCreateHotelRoom(t, repo, roomType)
+	occupied := storagetest.MustCreateHotelRoom(t, repo, roomType)
+	storagetest.MustCreateVisitor(t, repo, occupied)
+
+	roomPath := "/rooms/" + strconv.Itoa(room.Id)
+	body := fmt.Sprintf(`{"hotel_id":%d,"room_type_id":%d,"room_number":3,"floor":1}`, hotel.Id, roomType.Id)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"update", http.MethodPut, roomPath, body, http.StatusOK},
+		{"update missing room", http.MethodPut, "/rooms/999", body, http.StatusNotFound},
+		{"update missing hotel", http.MethodPut, roomPath, fmt.Sprintf(`{"hotel_id":999,"room_type_id":%d,"room_number":3}`, roomType.Id), http.StatusNotFound},
+		{"update taken number", http.MethodPut, roomPath, fmt.Sprintf(`{"hotel_id":%d,"room_type_id":%d,"room_number":%d}`, hotel.Id, roomType.Id, occupied.RoomNumber), http.StatusConflict},
+		{"delete occupied", http.MethodDelete, "/rooms/" + strconv.Itoa(occupied.Id), "", http.StatusConflict},
+		{"delete", http.MethodDelete, roomPath, "", http.StatusNoContent},
+		{"delete again", http.MethodDelete, roomPath, "", http.StatusNotFound},
+		{"invalid id", http.MethodDelete, "/rooms/abc", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package hotelRoomHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateHotelRoom interface {
+	UpdateHotelRoom(ctx context.Context, id int, hotelId int, roomTypeId int, roomNumber int, floor int) (models.HotelRoom, error)
+}
+
+func PutHotelRoomHandler(log *slog.Logger, updateHotelRoom UpdateHotelRoom) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.hotelRoomHandlers.PutHotelRoomHandler"
+		var room models.HotelRoom
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("roomId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room id", slog.String("id", c.Param("roomId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &room); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		updated, err := updateHotelRoom.UpdateHotelRoom(ctx, id, room.HotelId, room.RoomTypeId, room.RoomNumber, room.Floor)
+		if err != nil {
+			log.InfoContext(ctx, "failed to update hotel room", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "hotel room updated", slog.Int("id", id))
+
+		c.JSON(http.StatusOK, updated)
+	}
+}
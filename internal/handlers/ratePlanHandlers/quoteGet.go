@@ -0,0 +1,73 @@
+package ratePlanHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuoteStay interface {
+	QuoteStay(ctx context.Context, roomTypeId int, ratePlanId int, checkIn models.Date, checkOut models.Date, guests int) (models.Quote, error)
+}
+
+// quoteQuery is the query of a quote request. Without rate_plan_id the
+// cheapest plan of the room type is quoted, as a reservation would be priced.
+type quoteQuery struct {
+	CheckIn    models.Date `form:"check_in"`
+	CheckOut   models.Date `form:"check_out"`
+	Guests     int         `form:"guests,default=1" binding:"gt=0"`
+	RatePlanId int         `form:"rate_plan_id" binding:"gte=0"`
+}
+
+func GetQuoteHandler(log *slog.Logger, quoteStay QuoteStay) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.ratePlanHandlers.GetQuoteHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomTypeId, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		var query quoteQuery
+		if err := bind.Query(c, &query); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		if query.CheckIn.IsZero() || !query.CheckOut.After(query.CheckIn.Time) {
+			log.InfoContext(ctx, "invalid stay")
+
+			c.Error(storage.NewError(storage.ErrValidation, "check_out must be after check_in"))
+
+			return
+		}
+
+		quote, err := quoteStay.QuoteStay(ctx, roomTypeId, query.RatePlanId, query.CheckIn, query.CheckOut, query.Guests)
+		if err != nil {
+			log.InfoContext(ctx, "failed to quote stay", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, quote)
+	}
+}
@@ -0,0 +1,59 @@
+package ratePlanHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateRatePlan interface {
+	CreateRatePlan(ctx context.Context, rp models.RatePlan) (models.RatePlan, error)
+}
+
+func PostRatePlanHandler(log *slog.Logger, createRatePlan CreateRatePlan) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.ratePlanHandlers.PostRatePlanHandler"
+		var ratePlan models.RatePlan
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomTypeId, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &ratePlan); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		ratePlan.RoomTypeId = roomTypeId
+
+		created, err := createRatePlan.CreateRatePlan(ctx, ratePlan)
+		if err != nil {
+			log.InfoContext(ctx, "failed to create rate plan", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "rate plan created", slog.Int("id", created.Id), slog.Int("room_type_id", roomTypeId))
+
+		c.JSON(http.StatusCreated, created)
+	}
+}
@@ -0,0 +1,47 @@
+package ratePlanHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteRatePlan interface {
+	DeleteRatePlan(ctx context.Context, id int) error
+}
+
+func DeleteRatePlanHandler(log *slog.Logger, deleteRatePlan DeleteRatePlan) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.ratePlanHandlers.DeleteRatePlanHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("ratePlanId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid rate plan id", slog.String("id", c.Param("ratePlanId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid rate plan id"))
+
+			return
+		}
+
+		err = deleteRatePlan.DeleteRatePlan(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to delete rate plan", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "rate plan deleted", slog.Int("id", id))
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,46 @@
+package ratePlanHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetRatePlan interface {
+	GetRatePlan(ctx context.Context, id int) (models.RatePlan, error)
+}
+
+func GetRatePlanHandler(log *slog.Logger, getRatePlan GetRatePlan) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.ratePlanHandlers.GetRatePlanHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("ratePlanId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid rate plan id", slog.String("id", c.Param("ratePlanId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid rate plan id"))
+
+			return
+		}
+
+		ratePlan, err := getRatePlan.GetRatePlan(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get rate plan", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, ratePlan)
+	}
+}
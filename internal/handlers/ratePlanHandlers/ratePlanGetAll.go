@@ -0,0 +1,46 @@
+package ratePlanHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListRatePlans interface {
+	ListRatePlans(ctx context.Context, roomTypeId int) ([]models.RatePlan, error)
+}
+
+func GetRatePlansByRoomTypeHandler(log *slog.Logger, listRatePlans ListRatePlans) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.ratePlanHandlers.GetRatePlansByRoomTypeHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomTypeId, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		ratePlans, err := listRatePlans.ListRatePlans(ctx, roomTypeId)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get rate plans", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, ratePlans)
+	}
+}
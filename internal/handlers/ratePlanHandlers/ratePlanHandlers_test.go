@@ -0,0 +1,164 @@
+package ratePlanHandlers_test
+
+import (
+	"bookings/internal/handlers/ratePlanHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setup(t *testing.T) (*gin.Engine, *memory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.GET("/room-types/:roomTypeId/rate-plans", ratePlanHandlers.GetRatePlansByRoomTypeHandler(log, repo))
+	r.POST("/room-types/:roomTypeId/rate-plans", ratePlanHandlers.PostRatePlanHandler(log, repo))
+	r.GET("/room-types/:roomTypeId/quote", ratePlanHandlers.GetQuoteHandler(log, repo))
+	r.GET("/rate-plans/:ratePlanId", ratePlanHandlers.GetRatePlanHandler(log, repo))
+	r.PUT("/rate-plans/:ratePlanId", ratePlanHandlers.PutRatePlanHandler(log, repo))
+	r.DELETE("/rate-plans/:ratePlanId", ratePlanHandlers.DeleteRatePlanHandler(log, repo))
+
+	return r, repo
+}
+
+func perform(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRatePlans(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Spain", "Seville", "Alfonso XIII", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	plansPath := "/room-types/" + strconv.Itoa(roomType.Id) + "/rate-plans"
+
+	body := `{"name":"Flexible","currency":"EUR","base_price":"120.00","weekend_price":150,
+		"seasons":[{"from":"2030-08-01","to":"2030-08-31","price":"180.50"}],
+		"stay_discounts":[{"min_nights":7,"percent":10}]}`
+
+	w := perform(r, http.MethodPost, plansPath, body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var plan models.RatePlan
+	if err := json.Unmarshal(w.Body.Bytes(), &plan); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if plan.RoomTypeId != roomType.Id || plan.BasePrice != 12000 || plan.WeekendPrice != 15000 || plan.Seasons[0].Price != 18050 {
+		t.Fatalf("created rate plan = %+v", plan)
+	}
+	planPath := "/rate-plans/" + strconv.Itoa(plan.Id)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"same name", http.MethodPost, plansPath, body, http.StatusConflict},
+		{"missing room type", http.MethodPost, "/room-types/999/rate-plans", body, http.StatusNotFound},
+		{"invalid currency", http.MethodPost, plansPath, `{"name":"Saver","currency":"XYZ","base_price":"90"}`, http.StatusBadRequest},
+		{"yen", http.MethodPost, plansPath, `{"name":"Saver","currency":"JPY","base_price":"9000"}`, http.StatusBadRequest},
+		{"fractional cents", http.MethodPost, plansPath, `{"name":"Saver","currency":"EUR","base_price":"90.001"}`, http.StatusBadRequest},
+		{"overflowing price", http.MethodPost, plansPath, `{"name":"Saver","currency":"EUR","base_price":184467440737095517}`, http.StatusBadRequest},
+		{"price too high", http.MethodPost, plansPath, `{"name":"Saver","currency":"EUR","base_price":"10000000.01"}`, http.StatusBadRequest},
+		{"free", http.MethodPost, plansPath, `{"name":"Saver","currency":"EUR","base_price":"0"}`, http.StatusBadRequest},
+		{"one night discount", http.MethodPost, plansPath, `{"name":"Saver","currency":"EUR","base_price":"90","stay_discounts":[{"min_nights":1,"percent":5}]}`, http.StatusBadRequest},
+		{"overlapping seasons", http.MethodPost, plansPath, `{"name":"Saver","currency":"EUR","base_price":"90","seasons":[{"from":"2030-08-01","to":"2030-08-10","price":"100"},{"from":"2030-08-10","to":"2030-08-20","price":"110"}]}`, http.StatusBadRequest},
+		{"list", http.MethodGet, plansPath, "", http.StatusOK},
+		{"list missing room type", http.MethodGet, "/room-types/999/rate-plans", "", http.StatusNotFound},
+		{"get", http.MethodGet, planPath, "", http.StatusOK},
+		{"get missing", http.MethodGet, "/rate-plans/999", "", http.StatusNotFound},
+		{"invalid id", http.MethodGet, "/rate-plans/abc", "", http.StatusBadRequest},
+		{"update", http.MethodPut, planPath, `{"name":"Flexible","currency":"EUR","base_price":"125"}`, http.StatusOK},
+		{"update missing", http.MethodPut, "/rate-plans/999", `{"name":"Flexible","currency":"EUR","base_price":"125"}`, http.StatusNotFound},
+		{"quote", http.MethodGet, "/room-types/" + strconv.Itoa(roomType.Id) + "/quote?check_in=2030-07-01&check_out=2030-07-03", "", http.StatusOK},
+		{"delete", http.MethodDelete, planPath, "", http.StatusNoContent},
+		{"delete again", http.MethodDelete, planPath, "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestGetQuote(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Spain", "Seville", "Alfonso XIII", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	unpriced := storagetest.MustCreateRoomType(t, repo, hotel.Id, 1)
+	plan := storagetest.MustCreateRatePlan(t, repo, roomType.Id, "Flexible", 9950)
+
+	quotePath := "/room-types/" + strconv.Itoa(roomType.Id) + "/quote"
+
+	w := perform(r, http.MethodGet, quotePath+"?check_in=2030-07-01&check_out=2030-07-04&guests=2", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body)
+	}
+
+	var quote models.Quote
+	if err := json.Unmarshal(w.Body.Bytes(), &quote); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if quote.RatePlanId != plan.Id || len(quote.Nights) != 3 || quote.Total != 29850 {
+		t.Fatalf("quote = %+v, want three nights at 99.50", quote)
+	}
+	if !strings.Contains(w.Body.String(), `"total":"298.50"`) {
+		t.Fatalf("body %s, want the total as a decimal string", w.Body)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"named plan", quotePath + "?check_in=2030-07-01&check_out=2030-07-04&rate_plan_id=" + strconv.Itoa(plan.Id), http.StatusOK},
+		{"other plan", quotePath + "?check_in=2030-07-01&check_out=2030-07-04&rate_plan_id=999", http.StatusBadRequest},
+		{"unpriced room type", "/room-types/" + strconv.Itoa(unpriced.Id) + "/quote?check_in=2030-07-01&check_out=2030-07-04", http.StatusNotFound},
+		{"missing room type", "/room-types/999/quote?check_in=2030-07-01&check_out=2030-07-04", http.StatusNotFound},
+		{"too many guests", quotePath + "?check_in=2030-07-01&check_out=2030-07-04&guests=3", http.StatusBadRequest},
+		{"missing dates", quotePath, http.StatusBadRequest},
+		{"reversed dates", quotePath + "?check_in=2030-07-04&check_out=2030-07-01", http.StatusBadRequest},
+		{"stay too long", quotePath + "?check_in=2026-01-01&check_out=9999-12-31", http.StatusBadRequest},
+		{"bad date", quotePath + "?check_in=tomorrow&check_out=2030-07-04", http.StatusBadRequest},
+		{"bad guests", quotePath + "?check_in=2030-07-01&check_out=2030-07-04&guests=0", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, http.MethodGet, tt.path, "")
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package ratePlanHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateRatePlan interface {
+	UpdateRatePlan(ctx context.Context, id int, rp models.RatePlan) (models.RatePlan, error)
+}
+
+func PutRatePlanHandler(log *slog.Logger, updateRatePlan UpdateRatePlan) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.ratePlanHandlers.PutRatePlanHandler"
+		var ratePlan models.RatePlan
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("ratePlanId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid rate plan id", slog.String("id", c.Param("ratePlanId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid rate plan id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &ratePlan); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		updated, err := updateRatePlan.UpdateRatePlan(ctx, id, ratePlan)
+		if err != nil {
+			log.InfoContext(ctx, "failed to update rate plan", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "rate plan updated", slog.Int("id", id))
+
+		c.JSON(http.StatusOK, updated)
+	}
+}
@@ -0,0 +1,48 @@
+package reservationHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CancelReservation interface {
+	CancelReservation(ctx context.Context, id int) (models.Reservation, error)
+}
+
+func CancelReservationHandler(log *slog.Logger, cancelReservation CancelReservation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.CancelReservationHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("reservationId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid reservation id", slog.String("id", c.Param("reservationId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid reservation id"))
+
+			return
+		}
+
+		cancelled, err := cancelReservation.CancelReservation(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to cancel reservation", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "reservation cancelled", slog.Int("id", id))
+
+		c.JSON(http.StatusOK, cancelled)
+	}
+}
@@ -0,0 +1,66 @@
+package reservationHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CheckInReservation interface {
+	CheckInReservation(ctx context.Context, id int, hotelRoomId int) (models.Reservation, error)
+}
+
+// checkInRequest names the room to assign. Without one the reservation keeps
+// a room assigned earlier or gets the first free room of its type.
+type checkInRequest struct {
+	HotelRoomId int `json:"hotel_room_id" binding:"omitempty,gt=0"`
+}
+
+func CheckInReservationHandler(log *slog.Logger, checkInReservation CheckInReservation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.CheckInReservationHandler"
+		var req checkInRequest
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("reservationId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid reservation id", slog.String("id", c.Param("reservationId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid reservation id"))
+
+			return
+		}
+
+		if c.Request.ContentLength != 0 {
+			if err := bind.JSON(c, &req); err != nil {
+				log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+				c.Error(err)
+
+				return
+			}
+		}
+
+		checkedIn, err := checkInReservation.CheckInReservation(ctx, id, req.HotelRoomId)
+		if err != nil {
+			log.InfoContext(ctx, "failed to check in reservation", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "reservation checked in", slog.Int("id", id), slog.Int("hotel_room_id", checkedIn.HotelRoomId))
+
+		c.JSON(http.StatusOK, checkedIn)
+	}
+}
@@ -0,0 +1,48 @@
+package reservationHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConfirmReservation interface {
+	ConfirmReservation(ctx context.Context, id int) (models.Reservation, error)
+}
+
+func ConfirmReservationHandler(log *slog.Logger, confirmReservation ConfirmReservation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.ConfirmReservationHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("reservationId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid reservation id", slog.String("id", c.Param("reservationId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid reservation id"))
+
+			return
+		}
+
+		confirmed, err := confirmReservation.ConfirmReservation(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to confirm reservation", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "reservation confirmed", slog.Int("id", id))
+
+		c.JSON(http.StatusOK, confirmed)
+	}
+}
@@ -0,0 +1,56 @@
+package reservationHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateReservation interface {
+	CreateReservation(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int) (models.Reservation, error)
+}
+
+func PostReservationHandler(log *slog.Logger, createReservation CreateReservation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.PostReservationHandler"
+		var res models.Reservation
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		if err := bind.JSON(c, &res); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		if res.CheckIn.IsZero() || !res.CheckOut.After(res.CheckIn.Time) || storage.StayTooLong(res.CheckIn, res.CheckOut) || res.Guests <= 0 {
+			log.InfoContext(ctx, "invalid reservation")
+
+			c.Error(storage.ErrReservationInvalid)
+
+			return
+		}
+
+		created, err := createReservation.CreateReservation(ctx, res.RoomTypeId, res.RatePlanId, res.VisitorId, res.CheckIn, res.CheckOut, res.Guests)
+		if err != nil {
+			log.InfoContext(ctx, "failed to create reservation", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "reservation created", slog.Int("id", created.Id), slog.Int("room_type_id", res.RoomTypeId))
+
+		c.JSON(http.StatusCreated, created)
+	}
+}
@@ -0,0 +1,46 @@
+package reservationHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetReservation interface {
+	GetReservation(ctx context.Context, id int) (models.Reservation, error)
+}
+
+func GetReservationHandler(log *slog.Logger, getReservation GetReservation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.GetReservationHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("reservationId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid reservation id", slog.String("id", c.Param("reservationId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid reservation id"))
+
+			return
+		}
+
+		reservation, err := getReservation.GetReservation(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get reservation", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, reservation)
+	}
+}
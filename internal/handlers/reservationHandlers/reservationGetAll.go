@@ -0,0 +1,125 @@
+package reservationHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListReservations interface {
+	ListReservations(ctx context.Context, filter storage.ReservationFilter) (models.Page[models.Reservation], error)
+}
+
+func GetAllReservationsHandler(log *slog.Logger, listReservations ListReservations) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.GetAllReservationsHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		var filter storage.ReservationFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		reservations, err := listReservations.ListReservations(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get reservations", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, reservations)
+	}
+}
+
+func GetReservationsByHotelHandler(log *slog.Logger, listReservations ListReservations) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.GetReservationsByHotelHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		hotelId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		var filter storage.ReservationFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		filter.HotelId = hotelId
+
+		reservations, err := listReservations.ListReservations(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get reservations", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, reservations)
+	}
+}
+
+func GetReservationsByHotelRoomHandler(log *slog.Logger, listReservations ListReservations) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.GetReservationsByHotelRoomHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomId, err := strconv.Atoi(c.Param("roomId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room id", slog.String("id", c.Param("roomId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room id"))
+
+			return
+		}
+
+		var filter storage.ReservationFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		filter.HotelRoomId = roomId
+
+		reservations, err := listReservations.ListReservations(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get reservations", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, reservations)
+	}
+}
@@ -0,0 +1,227 @@
+package reservationHandlers_test
+
+import (
+	"bookings/internal/handlers/reservationHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setup(t *testing.T) (*gin.Engine, *memory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.GET("/hotel/:id/reservations", reservationHandlers.GetReservationsByHotelHandler(log, repo))
+	r.GET("/rooms/:roomId/reservations", reservationHandlers.GetReservationsByHotelRoomHandler(log, repo))
+	r.POST("/reservations/", reservationHandlers.PostReservationHandler(log, repo))
+	r.GET("/reservations/", reservationHandlers.GetAllReservationsHandler(log, repo))
+	r.POST("/reservations/holds", reservationHandlers.PostHoldHandler(log, repo))
+	r.GET("/reservations/:reservationId", reservationHandlers.GetReservationHandler(log, repo))
+	r.POST("/reservations/:reservationId/confirm", reservationHandlers.ConfirmReservationHandler(log, repo))
+	r.POST("/reservations/:reservationId/cancel", reservationHandlers.CancelReservationHandler(log, repo))
+	r.POST("/reservations/:reservationId/check-in", reservationHandlers.CheckInReservationHandler(log, repo))
+
+	return r, repo
+}
+
+func perform(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestReservationLifecycle(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Austria", "Vienna", "Sacher", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := storagetest.MustCreateHotelRoom(t, repo, roomType)
+	vis := storagetest.MustCreateVisitor(t, repo, room)
+
+	body := func(roomTypeId int, checkIn, checkOut string, guests int) string {
+		return fmt.Sprintf(`{"room_type_id":%d,"visitor_id":%d,"check_in":%q,"check_out":%q,"guests":%d}`,
+			roomTypeId, vis.Id, checkIn, checkOut, guests)
+	}
+
+	w := perform(r, http.MethodPost, "/reservations/", body(roomType.Id, "2030-07-10", "2030-07-14", 2))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var res models.Reservation
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if res.Status != models.ReservationPending || res.CheckIn.String() != "2030-07-10" {
+		t.Fatalf("created reservation = %+v", res)
+	}
+
+	resPath := "/reservations/" + strconv.Itoa(res.Id)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"sold out", http.MethodPost, "/reservations/", body(roomType.Id, "2030-07-12", "2030-07-15", 1), http.StatusConflict},
+		{"checkout before checkin", http.MethodPost, "/reservations/", body(roomType.Id, "2030-07-20", "2030-07-18", 1), http.StatusBadRequest},
+		{"no guests", http.MethodPost, "/reservations/", body(roomType.Id, "2030-07-20", "2030-07-22", 0), http.StatusBadRequest},
+		{"too many guests", http.MethodPost, "/reservations/", body(roomType.Id, "2030-07-20", "2030-07-22", 3), http.StatusBadRequest},
+		{"bad date", http.MethodPost, "/reservations/", body(roomType.Id, "20.07.2030", "2030-07-22", 1), http.StatusBadRequest},
+		{"missing room type", http.MethodPost, "/reservations/", body(999, "2030-07-20", "2030-07-22", 1), http.StatusNotFound},
+		{"get", http.MethodGet, resPath, "", http.StatusOK},
+		{"get missing", http.MethodGet, "/reservations/999", "", http.StatusNotFound},
+		{"list", http.MethodGet, "/reservations/", "", http.StatusOK},
+		{"list by hotel", http.MethodGet, "/hotel/" + strconv.Itoa(hotel.Id) + "/reservations", "", http.StatusOK},
+		{"list by room", http.MethodGet, "/rooms/" + strconv.Itoa(room.Id) + "/reservations", "", http.StatusOK},
+		{"cancel", http.MethodPost, resPath + "/cancel", "", http.StatusOK},
+		{"cancel again", http.MethodPost, resPath + "/cancel", "", http.StatusConflict},
+		{"cancel missing", http.MethodPost, "/reservations/999/cancel", "", http.StatusNotFound},
+		{"rebook freed dates", http.MethodPost, "/reservations/", body(roomType.Id, "2030-07-12", "2030-07-15", 1), http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestCheckInReservation(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Austria", "Vienna", "Sacher", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := storagetest.MustCreateHotelRoom(t, repo, roomType)
+	other := storagetest.MustCreateHotelRoom(t, repo, storagetest.MustCreateRoomType(t, repo, hotel.Id, 2))
+	vis := storagetest.MustCreateVisitor(t, repo, room)
+
+	first := storagetest.MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-07-10", "2030-07-14")
+	second := storagetest.MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-07-20", "2030-07-22")
+
+	w := perform(r, http.MethodPost, "/reservations/"+strconv.Itoa(first.Id)+"/check-in", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var res models.Reservation
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if res.Status != models.ReservationCheckedIn || res.HotelRoomId != room.Id {
+		t.Fatalf("checked in reservation = %+v", res)
+	}
+
+	secondPath := "/reservations/" + strconv.Itoa(second.Id) + "/check-in"
+	roomBody := func(roomId int) string {
+		return fmt.Sprintf(`{"hotel_room_id":%d}`, roomId)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"checked in already", "/reservations/" + strconv.Itoa(first.Id) + "/check-in", "", http.StatusConflict},
+		{"room of another type", secondPath, roomBody(other.Id), http.StatusBadRequest},
+		{"missing room", secondPath, roomBody(999), http.StatusNotFound},
+		{"invalid room", secondPath, roomBody(-1), http.StatusBadRequest},
+		{"missing reservation", "/reservations/999/check-in", "", http.StatusNotFound},
+		{"invalid id", "/reservations/abc/check-in", "", http.StatusBadRequest},
+		{"chosen room", secondPath, roomBody(room.Id), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, http.MethodPost, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestHoldReservation(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Austria", "Vienna", "Sacher", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := storagetest.MustCreateHotelRoom(t, repo, roomType)
+	vis := storagetest.MustCreateVisitor(t, repo, room)
+
+	body := func(checkIn, checkOut string, extra string) string {
+		return fmt.Sprintf(`{"room_type_id":%d,"visitor_id":%d,"check_in":%q,"check_out":%q,"guests":1%s}`,
+			roomType.Id, vis.Id, checkIn, checkOut, extra)
+	}
+
+	start := time.Now()
+	w := perform(r, http.MethodPost, "/reservations/holds", body("2030-07-10", "2030-07-14", ""))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var res models.Reservation
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if res.Status != models.ReservationHeld || res.ExpiresAt == nil || res.ExpiresAt.Before(start.Add(15*time.Minute)) {
+		t.Fatalf("held reservation = %+v", res)
+	}
+
+	resPath := "/reservations/" + strconv.Itoa(res.Id)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"held dates", http.MethodPost, "/reservations/", body("2030-07-12", "2030-07-15", ""), http.StatusConflict},
+		{"hold held dates", http.MethodPost, "/reservations/holds", body("2030-07-12", "2030-07-15", ""), http.StatusConflict},
+		{"hold too long", http.MethodPost, "/reservations/holds", body("2030-08-01", "2030-08-02", `,"hold_minutes":61`), http.StatusBadRequest},
+		{"negative hold", http.MethodPost, "/reservations/holds", body("2030-08-01", "2030-08-02", `,"hold_minutes":-1`), http.StatusBadRequest},
+		{"hold checkout before checkin", http.MethodPost, "/reservations/holds", body("2030-08-02", "2030-08-01", ""), http.StatusBadRequest},
+		{"confirm", http.MethodPost, resPath + "/confirm", "", http.StatusOK},
+		{"confirm again", http.MethodPost, resPath + "/confirm", "", http.StatusConflict},
+		{"confirm missing", http.MethodPost, "/reservations/999/confirm", "", http.StatusNotFound},
+		{"confirm invalid id", http.MethodPost, "/reservations/abc/confirm", "", http.StatusBadRequest},
+		{"custom hold", http.MethodPost, "/reservations/holds", body("2030-08-01", "2030-08-02", `,"hold_minutes":5`), http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package reservationHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultHoldMinutes is how long a room is held when the request does not say.
+const defaultHoldMinutes = 15
+
+type HoldReservation interface {
+	HoldReservation(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int, holdFor time.Duration) (models.Reservation, error)
+}
+
+// holdRequest is a reservation to hold while the guest checks out.
+type holdRequest struct {
+	models.Reservation
+	HoldMinutes int `json:"hold_minutes" binding:"omitempty,gt=0,lte=60"`
+}
+
+func PostHoldHandler(log *slog.Logger, holdReservation HoldReservation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.reservationHandlers.PostHoldHandler"
+		var req holdRequest
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		if err := bind.JSON(c, &req); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		res := req.Reservation
+		if res.CheckIn.IsZero() || !res.CheckOut.After(res.CheckIn.Time) || storage.StayTooLong(res.CheckIn, res.CheckOut) || res.Guests <= 0 {
+			log.InfoContext(ctx, "invalid reservation")
+
+			c.Error(storage.ErrReservationInvalid)
+
+			return
+		}
+
+		if req.HoldMinutes == 0 {
+			req.HoldMinutes = defaultHoldMinutes
+		}
+
+		held, err := holdReservation.HoldReservation(ctx, res.RoomTypeId, res.RatePlanId, res.VisitorId, res.CheckIn, res.CheckOut, res.Guests, time.Duration(req.HoldMinutes)*time.Minute)
+		if err != nil {
+			log.InfoContext(ctx, "failed to hold reservation", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "reservation held", slog.Int("id", held.Id), slog.Int("room_type_id", res.RoomTypeId), slog.Int("minutes", req.HoldMinutes))
+
+		c.JSON(http.StatusCreated, held)
+	}
+}
@@ -0,0 +1,62 @@
+package restrictionHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListRestrictions interface {
+	ListRestrictions(ctx context.Context, roomTypeId int, from models.Date, to models.Date) ([]models.StayRestriction, error)
+}
+
+// restrictionQuery is the inclusive date range of a restriction listing.
+type restrictionQuery struct {
+	From models.Date `form:"from"`
+	To   models.Date `form:"to"`
+}
+
+func GetRestrictionsHandler(log *slog.Logger, listRestrictions ListRestrictions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.restrictionHandlers.GetRestrictionsHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomTypeId, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		var query restrictionQuery
+		if err := bind.Query(c, &query); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		restrictions, err := listRestrictions.ListRestrictions(ctx, roomTypeId, query.From, query.To)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get restrictions", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, restrictions)
+	}
+}
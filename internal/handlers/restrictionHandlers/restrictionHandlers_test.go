@@ -0,0 +1,124 @@
+package restrictionHandlers_test
+
+import (
+	"bookings/internal/handlers/reservationHandlers"
+	"bookings/internal/handlers/restrictionHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setup(t *testing.T) (*gin.Engine, *memory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.GET("/room-types/:roomTypeId/restrictions", restrictionHandlers.GetRestrictionsHandler(log, repo))
+	r.PATCH("/room-types/:roomTypeId/restrictions", restrictionHandlers.PatchRestrictionsHandler(log, repo))
+	r.POST("/reservations/", reservationHandlers.PostReservationHandler(log, repo))
+
+	return r, repo
+}
+
+func perform(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRestrictions(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Austria", "Vienna", "Sacher", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	path := "/room-types/" + strconv.Itoa(roomType.Id) + "/restrictions"
+
+	w := perform(r, http.MethodPatch, path, `{"from":"2030-07-01","to":"2030-07-31","weekdays":["fri","sat"],"min_stay":3}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("PATCH status = %d, body %s", w.Code, w.Body)
+	}
+
+	var restrictions []models.StayRestriction
+	if err := json.Unmarshal(w.Body.Bytes(), &restrictions); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if len(restrictions) != 8 || restrictions[0].Date.String() != "2030-07-05" || restrictions[0].MinStay != 3 {
+		t.Fatalf("restrictions = %+v, want minimum stays on the 8 July weekend days", restrictions)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"stop-sell", http.MethodPatch, path, `{"from":"2030-07-13","to":"2030-07-13","stop_sell":true}`, http.StatusOK},
+		{"list", http.MethodGet, path + "?from=2030-07-01&to=2030-07-31", "", http.StatusOK},
+		{"list without range", http.MethodGet, path, "", http.StatusBadRequest},
+		{"list bad date", http.MethodGet, path + "?from=july&to=2030-07-31", "", http.StatusBadRequest},
+		{"unknown weekday", http.MethodPatch, path, `{"from":"2030-07-01","to":"2030-07-31","weekdays":["friday"],"min_stay":3}`, http.StatusBadRequest},
+		{"negative stay", http.MethodPatch, path, `{"from":"2030-07-01","to":"2030-07-31","min_stay":-1}`, http.StatusBadRequest},
+		{"max below min", http.MethodPatch, path, `{"from":"2030-07-05","to":"2030-07-05","max_stay":2}`, http.StatusBadRequest},
+		{"reversed range", http.MethodPatch, path, `{"from":"2030-07-31","to":"2030-07-01","stop_sell":true}`, http.StatusBadRequest},
+		{"missing room type", http.MethodPatch, "/room-types/999/restrictions", `{"from":"2030-07-01","to":"2030-07-31","stop_sell":true}`, http.StatusNotFound},
+		{"invalid room type id", http.MethodGet, "/room-types/abc/restrictions?from=2030-07-01&to=2030-07-31", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestRestrictedReservation(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Austria", "Vienna", "Sacher", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	vis := storagetest.MustCreateVisitor(t, repo, storagetest.MustCreateHotelRoom(t, repo, roomType))
+
+	w := perform(r, http.MethodPatch, "/room-types/"+strconv.Itoa(roomType.Id)+"/restrictions",
+		`{"from":"2030-07-05","to":"2030-07-05","min_stay":3,"closed_to_arrival":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("PATCH status = %d, body %s", w.Code, w.Body)
+	}
+
+	body := fmt.Sprintf(`{"room_type_id":%d,"visitor_id":%d,"check_in":"2030-07-05","check_out":"2030-07-07","guests":1}`, roomType.Id, vis.Id)
+	w = perform(r, http.MethodPost, "/reservations/", body)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var problem middleware.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if len(problem.Errors) != 2 || problem.Errors[0].Rule != storage.RuleClosedToArrival || problem.Errors[1].Rule != storage.RuleMinStay {
+		t.Fatalf("problem = %+v, want closed_to_arrival and min_stay", problem)
+	}
+}
@@ -0,0 +1,61 @@
+package restrictionHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateRestrictions interface {
+	UpdateRestrictions(ctx context.Context, roomTypeId int, u models.RestrictionUpdate) ([]models.StayRestriction, error)
+}
+
+// PatchRestrictionsHandler applies one update to a range of dates, see
+// models.RestrictionUpdate, and responds with the restrictions of the range.
+func PatchRestrictionsHandler(log *slog.Logger, updateRestrictions UpdateRestrictions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.restrictionHandlers.PatchRestrictionsHandler"
+		var update models.RestrictionUpdate
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomTypeId, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &update); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		restrictions, err := updateRestrictions.UpdateRestrictions(ctx, roomTypeId, update)
+		if err != nil {
+			log.InfoContext(ctx, "failed to update restrictions", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "restrictions updated", slog.Int("room_type_id", roomTypeId),
+			slog.String("from", update.From.String()), slog.String("to", update.To.String()))
+
+		c.JSON(http.StatusOK, restrictions)
+	}
+}
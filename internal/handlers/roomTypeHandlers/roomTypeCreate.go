@@ -0,0 +1,59 @@
+package roomTypeHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateRoomType interface {
+	CreateRoomType(ctx context.Context, rt models.RoomType) (models.RoomType, error)
+}
+
+func PostRoomTypeHandler(log *slog.Logger, createRoomType CreateRoomType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.roomTypeHandlers.PostRoomTypeHandler"
+		var roomType models.RoomType
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		hotelId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &roomType); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		roomType.HotelId = hotelId
+
+		created, err := createRoomType.CreateRoomType(ctx, roomType)
+		if err != nil {
+			log.InfoContext(ctx, "failed to create room type", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "room type created", slog.Int("id", created.Id), slog.Int("hotel_id", hotelId))
+
+		c.JSON(http.StatusCreated, created)
+	}
+}
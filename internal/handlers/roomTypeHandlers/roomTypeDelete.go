@@ -0,0 +1,47 @@
+package roomTypeHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteRoomType interface {
+	DeleteRoomType(ctx context.Context, id int) error
+}
+
+func DeleteRoomTypeHandler(log *slog.Logger, deleteRoomType DeleteRoomType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.roomTypeHandlers.DeleteRoomTypeHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		err = deleteRoomType.DeleteRoomType(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to delete room type", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "room type deleted", slog.Int("id", id))
+
+		c.Status(http.StatusNoContent)
+	}
+}
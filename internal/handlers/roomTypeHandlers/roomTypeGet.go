@@ -0,0 +1,46 @@
+package roomTypeHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetRoomType interface {
+	GetRoomType(ctx context.Context, id int) (models.RoomType, error)
+}
+
+func GetRoomTypeHandler(log *slog.Logger, getRoomType GetRoomType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.roomTypeHandlers.GetRoomTypeHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		roomType, err := getRoomType.GetRoomType(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get room type", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, roomType)
+	}
+}
@@ -0,0 +1,86 @@
+package roomTypeHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListRoomTypes interface {
+	ListRoomTypes(ctx context.Context, filter storage.RoomTypeFilter) (models.Page[models.RoomType], error)
+}
+
+func GetAllRoomTypesHandler(log *slog.Logger, listRoomTypes ListRoomTypes) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.roomTypeHandlers.GetAllRoomTypesHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		var filter storage.RoomTypeFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		roomTypes, err := listRoomTypes.ListRoomTypes(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get room types", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, roomTypes)
+	}
+}
+
+func GetRoomTypesByHotelHandler(log *slog.Logger, listRoomTypes ListRoomTypes) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.roomTypeHandlers.GetRoomTypesByHotelHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		hotelId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		var filter storage.RoomTypeFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		filter.HotelId = hotelId
+
+		roomTypes, err := listRoomTypes.ListRoomTypes(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get room types", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, roomTypes)
+	}
+}
@@ -0,0 +1,150 @@
+package roomTypeHandlers_test
+
+import (
+	"bookings/internal/handlers/roomTypeHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setup(t *testing.T) (*gin.Engine, *memory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.GET("/hotel/:id/room-types", roomTypeHandlers.GetRoomTypesByHotelHandler(log, repo))
+	r.POST("/hotel/:id/room-types", roomTypeHandlers.PostRoomTypeHandler(log, repo))
+	r.GET("/room-types/", roomTypeHandlers.GetAllRoomTypesHandler(log, repo))
+	r.GET("/room-types/:roomTypeId", roomTypeHandlers.GetRoomTypeHandler(log, repo))
+	r.PUT("/room-types/:roomTypeId", roomTypeHandlers.PutRoomTypeHandler(log, repo))
+	r.DELETE("/room-types/:roomTypeId", roomTypeHandlers.DeleteRoomTypeHandler(log, repo))
+
+	return r, repo
+}
+
+func perform(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateAndGetRoomType(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Portugal", "Lisbon", "Pestana", 5)
+	hotelPath := "/hotel/" + strconv.Itoa(hotel.Id) + "/room-types"
+	body := `{"name":"Family","max_adults":2,"max_children":2,"bed_configuration":"1 king, 2 single","base_occupancy":2,"size_sqm":35}`
+
+	w := perform(r, http.MethodPost, hotelPath, body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var roomType models.RoomType
+	if err := json.Unmarshal(w.Body.Bytes(), &roomType); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if roomType.HotelId != hotel.Id || roomType.MaxGuests() != 4 || roomType.SizeSqm != 35 {
+		t.Fatalf("created room type = %+v", roomType)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"same name", hotelPath, body, http.StatusConflict},
+		{"missing hotel", "/hotel/999/room-types", `{"name":"Single","max_adults":1,"base_occupancy":1}`, http.StatusNotFound},
+		{"no adults", hotelPath, `{"name":"Kids","max_children":2,"base_occupancy":1}`, http.StatusBadRequest},
+		{"occupancy above capacity", hotelPath, `{"name":"Tight","max_adults":1,"base_occupancy":2}`, http.StatusBadRequest},
+		{"invalid hotel id", "/hotel/abc/room-types", body, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, http.MethodPost, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	w = perform(r, http.MethodGet, "/room-types/"+strconv.Itoa(roomType.Id), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", w.Code)
+	}
+
+	w = perform(r, http.MethodGet, "/room-types/999", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("GET missing room type status = %d", w.Code)
+	}
+
+	for _, path := range []string{hotelPath, "/room-types/?guests_min=4"} {
+		w = perform(r, http.MethodGet, path, "")
+
+		var roomTypes models.Page[models.RoomType]
+		if err := json.Unmarshal(w.Body.Bytes(), &roomTypes); err != nil {
+			t.Fatalf("GET %s: invalid body %s: %v", path, w.Body, err)
+		}
+		if len(roomTypes.Items) != 1 || roomTypes.Items[0] != roomType {
+			t.Fatalf("GET %s = %+v", path, roomTypes)
+		}
+	}
+}
+
+func TestUpdateAndDeleteRoomType(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Portugal", "Lisbon", "Pestana", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	withRooms := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	storagetest.MustCreateHotelRoom(t, repo, withRooms)
+
+	typePath := "/room-types/" + strconv.Itoa(roomType.Id)
+	body := `{"name":"Double Deluxe","max_adults":2,"base_occupancy":2}`
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"update", http.MethodPut, typePath, body, http.StatusOK},
+		{"update to taken name", http.MethodPut, typePath, `{"name":"` + withRooms.Name + `","max_adults":2,"base_occupancy":2}`, http.StatusConflict},
+		{"update missing room type", http.MethodPut, "/room-types/999", body, http.StatusNotFound},
+		{"update without name", http.MethodPut, typePath, `{"max_adults":2,"base_occupancy":2}`, http.StatusBadRequest},
+		{"delete with rooms", http.MethodDelete, "/room-types/" + strconv.Itoa(withRooms.Id), "", http.StatusConflict},
+		{"delete", http.MethodDelete, typePath, "", http.StatusNoContent},
+		{"delete again", http.MethodDelete, typePath, "", http.StatusNotFound},
+		{"invalid id", http.MethodDelete, "/room-types/abc", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
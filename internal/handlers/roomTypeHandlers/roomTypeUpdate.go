@@ -0,0 +1,58 @@
+package roomTypeHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateRoomType interface {
+	UpdateRoomType(ctx context.Context, id int, rt models.RoomType) (models.RoomType, error)
+}
+
+func PutRoomTypeHandler(log *slog.Logger, updateRoomType UpdateRoomType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.roomTypeHandlers.PutRoomTypeHandler"
+		var roomType models.RoomType
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("roomTypeId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room type id", slog.String("id", c.Param("roomTypeId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room type id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &roomType); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		updated, err := updateRoomType.UpdateRoomType(ctx, id, roomType)
+		if err != nil {
+			log.InfoContext(ctx, "failed to update room type", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "room type updated", slog.Int("id", id))
+
+		c.JSON(http.StatusOK, updated)
+	}
+}
@@ -0,0 +1,47 @@
+package visitorHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateVisitor interface {
+	CreateVisitor(ctx context.Context, hotelId int, hotelRoom int, firstName string, lastName string, age int) (models.Visitor, error)
+}
+
+func PostVisitorHandler(log *slog.Logger, createVisitor CreateVisitor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.visitorHandlers.PostVisitorHandler"
+		var visitor models.Visitor
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		if err := bind.JSON(c, &visitor); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		created, err := createVisitor.CreateVisitor(ctx, visitor.HotelId, visitor.HotelRoom, visitor.FirstName, visitor.LastName, visitor.Age)
+		if err != nil {
+			log.InfoContext(ctx, "failed to create visitor", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "visitor created", slog.Any("visitor", created))
+
+		c.JSON(http.StatusCreated, created)
+	}
+}
@@ -0,0 +1,47 @@
+package visitorHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteVisitor interface {
+	DeleteVisitor(ctx context.Context, id int) error
+}
+
+func DeleteVisitorHandler(log *slog.Logger, deleteVisitor DeleteVisitor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.visitorHandlers.DeleteVisitorHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("visitorId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid visitor id", slog.String("id", c.Param("visitorId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid visitor id"))
+
+			return
+		}
+
+		err = deleteVisitor.DeleteVisitor(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to delete visitor", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "visitor deleted", slog.Int("id", id))
+
+		c.Status(http.StatusNoContent)
+	}
+}
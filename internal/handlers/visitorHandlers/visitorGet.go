@@ -0,0 +1,46 @@
+package visitorHandlers
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetVisitor interface {
+	GetVisitor(ctx context.Context, id int) (models.Visitor, error)
+}
+
+func GetVisitorHandler(log *slog.Logger, getVisitor GetVisitor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.visitorHandlers.GetVisitorHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("visitorId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid visitor id", slog.String("id", c.Param("visitorId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid visitor id"))
+
+			return
+		}
+
+		visitor, err := getVisitor.GetVisitor(ctx, id)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get visitor", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, visitor)
+	}
+}
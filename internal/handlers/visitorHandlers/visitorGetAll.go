@@ -0,0 +1,125 @@
+package visitorHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListVisitors interface {
+	ListVisitors(ctx context.Context, filter storage.VisitorFilter) (models.Page[models.Visitor], error)
+}
+
+func GetAllVisitorsHandler(log *slog.Logger, listVisitors ListVisitors) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.visitorHandlers.GetAllVisitorsHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		var filter storage.VisitorFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		visitors, err := listVisitors.ListVisitors(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get visitors", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, visitors)
+	}
+}
+
+func GetVisitorsByHotelHandler(log *slog.Logger, listVisitors ListVisitors) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.visitorHandlers.GetVisitorsByHotelHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		hotelId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid hotel id", slog.String("id", c.Param("id")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid hotel id"))
+
+			return
+		}
+
+		var filter storage.VisitorFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		filter.HotelId = hotelId
+
+		visitors, err := listVisitors.ListVisitors(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get visitors", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, visitors)
+	}
+}
+
+func GetVisitorsByHotelRoomHandler(log *slog.Logger, listVisitors ListVisitors) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.visitorHandlers.GetVisitorsByHotelRoomHandler"
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		roomId, err := strconv.Atoi(c.Param("roomId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid room id", slog.String("id", c.Param("roomId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid room id"))
+
+			return
+		}
+
+		var filter storage.VisitorFilter
+		if err := bind.Query(c, &filter); err != nil {
+			log.InfoContext(ctx, "invalid query", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+		filter.HotelRoomId = roomId
+
+		visitors, err := listVisitors.ListVisitors(ctx, filter)
+		if err != nil {
+			log.InfoContext(ctx, "failed to get visitors", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, visitors)
+	}
+}
@@ -0,0 +1,102 @@
+package visitorHandlers_test
+
+import (
+	"bookings/internal/handlers/visitorHandlers"
+	"bookings/internal/middleware"
+	"bookings/internal/models"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setup(t *testing.T) (*gin.Engine, *memory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	r := gin.New()
+	r.Use(middleware.Problems(log))
+	r.GET("/hotel/:id/visitors", visitorHandlers.GetVisitorsByHotelHandler(log, repo))
+	r.GET("/rooms/:roomId/visitors", visitorHandlers.GetVisitorsByHotelRoomHandler(log, repo))
+	r.POST("/visitors/", visitorHandlers.PostVisitorHandler(log, repo))
+	r.GET("/visitors/", visitorHandlers.GetAllVisitorsHandler(log, repo))
+	r.GET("/visitors/:visitorId", visitorHandlers.GetVisitorHandler(log, repo))
+	r.PUT("/visitors/:visitorId", visitorHandlers.PutVisitorHandler(log, repo))
+	r.DELETE("/visitors/:visitorId", visitorHandlers.DeleteVisitorHandler(log, repo))
+
+	return r, repo
+}
+
+func perform(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestVisitorLifecycle(t *testing.T) {
+	r, repo := setup(t)
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Spain", "Madrid", "Palace", 5)
+	room := storagetest.MustCreateHotelRoom(t, repo, storagetest.MustCreateRoomType(t, repo, hotel.Id, 2))
+
+	body := func(age int) string {
+		return fmt.Sprintf(`{"hotel_id":%d,"hotel_room_id":%d,"first_name":"Ana","last_name":"Lopez","age":%d}`, hotel.Id, room.Id, age)
+	}
+
+	w := perform(r, http.MethodPost, "/visitors/", body(30))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST status = %d, body %s", w.Code, w.Body)
+	}
+
+	var vis models.Visitor
+	if err := json.Unmarshal(w.Body.Bytes(), &vis); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+
+	visitorPath := "/visitors/" + strconv.Itoa(vis.Id)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"create underage", http.MethodPost, "/visitors/", body(16), http.StatusBadRequest},
+		{"create in missing room", http.MethodPost, "/visitors/", `{"hotel_id":1,"hotel_room_id":999,"first_name":"Ana","last_name":"Lopez","age":30}`, http.StatusNotFound},
+		{"get", http.MethodGet, visitorPath, "", http.StatusOK},
+		{"get missing", http.MethodGet, "/visitors/999", "", http.StatusNotFound},
+		{"list", http.MethodGet, "/visitors/", "", http.StatusOK},
+		{"list by hotel", http.MethodGet, "/hotel/" + strconv.Itoa(hotel.Id) + "/visitors", "", http.StatusOK},
+		{"list by room", http.MethodGet, "/rooms/" + strconv.Itoa(room.Id) + "/visitors", "", http.StatusOK},
+		{"update", http.MethodPut, visitorPath, body(31), http.StatusOK},
+		{"update missing", http.MethodPut, "/visitors/999", body(31), http.StatusNotFound},
+		{"update too old", http.MethodPut, visitorPath, body(120), http.StatusBadRequest},
+		{"delete", http.MethodDelete, visitorPath, "", http.StatusNoContent},
+		{"delete again", http.MethodDelete, visitorPath, "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := perform(r, tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package visitorHandlers
+
+import (
+	"bookings/internal/handlers/bind"
+	"bookings/internal/logger"
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateVisitor interface {
+	UpdateVisitor(ctx context.Context, id int, hotelId int, hotelRoom int, firstName string, lastName string, age int) (models.Visitor, error)
+}
+
+func PutVisitorHandler(log *slog.Logger, updateVisitor UpdateVisitor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "handlers.visitorHandlers.PutVisitorHandler"
+		var visitor models.Visitor
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx, log).With(slog.String("op", op))
+
+		id, err := strconv.Atoi(c.Param("visitorId"))
+		if err != nil {
+			log.InfoContext(ctx, "invalid visitor id", slog.String("id", c.Param("visitorId")))
+
+			c.Error(storage.NewError(storage.ErrValidation, "invalid visitor id"))
+
+			return
+		}
+
+		if err := bind.JSON(c, &visitor); err != nil {
+			log.InfoContext(ctx, "invalid request body", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		updated, err := updateVisitor.UpdateVisitor(ctx, id, visitor.HotelId, visitor.HotelRoom, visitor.FirstName, visitor.LastName, visitor.Age)
+		if err != nil {
+			log.InfoContext(ctx, "failed to update visitor", logger.Err(err))
+
+			c.Error(err)
+
+			return
+		}
+
+		log.InfoContext(ctx, "visitor updated", slog.Any("visitor", updated))
+
+		c.JSON(http.StatusOK, updated)
+	}
+}
@@ -0,0 +1,120 @@
+// Package health serves the liveness and readiness endpoints used by the
+// orchestrator.
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CheckTimeout bounds every readiness check so a hung dependency reports a
+// failure instead of hanging the probe.
+const CheckTimeout = 2 * time.Second
+
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusDraining = "draining"
+)
+
+// Check reports whether one dependency is usable.
+type Check func(ctx context.Context) error
+
+// CheckResult is the outcome of one check in a readiness response.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the body of both endpoints; /healthz has no checks.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Checker holds the readiness checks and the draining flag. Checks are added
+// while wiring the application, before it serves requests.
+type Checker struct {
+	checks   map[string]Check
+	draining atomic.Bool
+}
+
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+func (h *Checker) Add(name string, check Check) {
+	h.checks[name] = check
+}
+
+// SetDraining makes readiness fail from now on, so the instance is taken out
+// of rotation while in-flight requests finish.
+func (h *Checker) SetDraining() {
+	h.draining.Store(true)
+}
+
+// Run executes all checks concurrently.
+func (h *Checker) Run(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(h.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := check(ctx)
+			result := CheckResult{Status: StatusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+			if err != nil {
+				result.Status, result.Error = StatusFail, err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = StatusFail
+			}
+		}()
+	}
+	wg.Wait()
+
+	if h.draining.Load() {
+		report.Status = StatusDraining
+	}
+
+	return report
+}
+
+// Healthz answers as long as the process can serve HTTP at all.
+func Healthz() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, Report{Status: StatusOK})
+	}
+}
+
+// Readyz runs the readiness checks and answers 503 unless all pass and the
+// application is not shutting down.
+func (h *Checker) Readyz() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := h.Run(c.Request.Context())
+
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+
+		c.JSON(status, report)
+	}
+}
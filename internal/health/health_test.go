@@ -0,0 +1,89 @@
+package health_test
+
+import (
+	"bookings/internal/health"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReadyz(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+	hung := func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+
+	tests := []struct {
+		name       string
+		checks     map[string]health.Check
+		draining   bool
+		wantStatus int
+		wantReport string
+		wantFailed []string
+	}{
+		{"all pass", map[string]health.Check{"postgres": ok, "migrations": ok}, false, http.StatusOK, health.StatusOK, nil},
+		{"one fails", map[string]health.Check{"postgres": failing, "migrations": ok}, false, http.StatusServiceUnavailable, health.StatusFail, []string{"postgres"}},
+		{"hung check times out", map[string]health.Check{"postgres": hung}, false, http.StatusServiceUnavailable, health.StatusFail, []string{"postgres"}},
+		{"draining", map[string]health.Check{"postgres": ok}, true, http.StatusServiceUnavailable, health.StatusDraining, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker()
+			for name, check := range tt.checks {
+				checker.Add(name, check)
+			}
+			if tt.draining {
+				checker.SetDraining()
+			}
+
+			r := gin.New()
+			r.GET("/readyz", checker.Readyz())
+
+			ctx, cancel := context.WithTimeout(context.Background(), health.CheckTimeout/10)
+			defer cancel()
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil).WithContext(ctx))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var report health.Report
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("invalid body %q: %v", w.Body.String(), err)
+			}
+			if report.Status != tt.wantReport {
+				t.Errorf("report status = %q, want %q", report.Status, tt.wantReport)
+			}
+			if len(report.Checks) != len(tt.checks) {
+				t.Errorf("got %d checks, want %d", len(report.Checks), len(tt.checks))
+			}
+			for _, name := range tt.wantFailed {
+				if c := report.Checks[name]; c.Status != health.StatusFail || c.Error == "" {
+					t.Errorf("check %s = %+v, want failure with error", name, c)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/healthz", health.Healthz())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if w.Code != http.StatusOK || w.Body.String() != `{"status":"ok"}` {
+		t.Errorf("got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,66 @@
+// Package holds releases the reservation holds that guests did not confirm
+// in time.
+package holds
+
+import (
+	"bookings/internal/logger"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// BatchSize bounds the holds expired by one statement, so a backlog is
+// worked off in short transactions.
+const BatchSize = 100
+
+// Expirer marks up to limit expired holds as expired and returns how many it
+// marked. Concurrent callers must not mark the same hold, so every instance
+// of the service can run a sweeper.
+type Expirer interface {
+	ExpireHolds(ctx context.Context, limit int) (int, error)
+}
+
+// Sweeper returns a worker that expires holds every interval until ctx is
+// cancelled.
+func Sweeper(log *slog.Logger, expirer Expirer, interval time.Duration) func(ctx context.Context) {
+	const op = "holds.Sweeper"
+	log = log.With(slog.String("op", op))
+
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				Sweep(ctx, log, expirer)
+			}
+		}
+	}
+}
+
+// Sweep expires holds in batches until a batch comes back short and returns
+// how many it expired.
+func Sweep(ctx context.Context, log *slog.Logger, expirer Expirer) int {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := expirer.ExpireHolds(ctx, BatchSize)
+		total += n
+		if err != nil {
+			if ctx.Err() == nil {
+				log.ErrorContext(ctx, "failed to expire holds", logger.Err(err))
+			}
+			break
+		}
+		if n < BatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.InfoContext(ctx, "holds expired", slog.Int("count", total))
+	}
+	return total
+}
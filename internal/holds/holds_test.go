@@ -0,0 +1,103 @@
+package holds_test
+
+import (
+	"bookings/internal/holds"
+	"bookings/internal/models"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSweeper(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+	ctx := context.Background()
+
+	hotel := storagetest.MustCreateHotel(t, repo, "Austria", "Vienna", "Sacher", 5)
+	roomType := storagetest.MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := storagetest.MustCreateHotelRoom(t, repo, roomType)
+	vis := storagetest.MustCreateVisitor(t, repo, room)
+
+	hold, err := repo.HoldReservation(ctx, roomType.Id, 0, vis.Id,
+		storagetest.Date(t, "2030-07-10"), storagetest.Date(t, "2030-07-12"), 1, 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("HoldReservation: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		holds.Sweeper(log, repo, 10*time.Millisecond)(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got, err := repo.GetReservation(context.Background(), hold.Id)
+		if err != nil {
+			t.Fatalf("GetReservation: %v", err)
+		}
+		if got.Status == models.ReservationExpired {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("hold status = %q, want expired", got.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not stop after cancel")
+	}
+}
+
+// batches expires the given counts one call at a time and then fails with
+// err, or expires nothing when err is nil.
+type batches struct {
+	counts []int
+	err    error
+	calls  int
+}
+
+func (b *batches) ExpireHolds(ctx context.Context, limit int) (int, error) {
+	b.calls++
+	if b.calls > len(b.counts) {
+		return 0, b.err
+	}
+	return min(b.counts[b.calls-1], limit), nil
+}
+
+func TestSweep(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name      string
+		expirer   *batches
+		want      int
+		wantCalls int
+	}{
+		{"nothing due", &batches{counts: []int{0}}, 0, 1},
+		{"one short batch", &batches{counts: []int{3}}, 3, 1},
+		{"backlog", &batches{counts: []int{holds.BatchSize, holds.BatchSize, 7}}, 2*holds.BatchSize + 7, 3},
+		{"error", &batches{counts: []int{holds.BatchSize}, err: errors.New("down")}, holds.BatchSize, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := holds.Sweep(context.Background(), log, tt.expirer); got != tt.want {
+				t.Fatalf("Sweep = %d, want %d", got, tt.want)
+			}
+			if tt.expirer.calls != tt.wantCalls {
+				t.Fatalf("ExpireHolds calls = %d, want %d", tt.expirer.calls, tt.wantCalls)
+			}
+		})
+	}
+}
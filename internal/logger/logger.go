@@ -1,16 +1,28 @@
 package logger
 
 import (
+	"bookings/internal/pii"
 	"log/slog"
 	"os"
 )
 
-func SetupLogger() {
+// SetupLogger installs the default logger. level and format are the validated
+// LOG_LEVEL and LOG_FORMAT settings. Records logged with a context carry the
+// trace and span id of the active span; personal data is masked, see pii.
+func SetupLogger(level, format string) {
+	var lvl slog.Level
+	_ = lvl.UnmarshalText([]byte(level))
 
-	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-	})
+	opts := &slog.HandlerOptions{
+		Level:       lvl,
+		ReplaceAttr: pii.ReplaceAttr,
+	}
 
-	logger := slog.New(handler)
+	var handler slog.Handler = slog.NewTextHandler(os.Stdout, opts)
+	if format == "json" {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+
+	logger := slog.New(traceHandler{handler})
 	slog.SetDefault(logger)
 }
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceHandler(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(traceHandler{slog.NewJSONHandler(&buf, nil)}).With(slog.String("op", "test"))
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tracetest.NewSpanRecorder()))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	log.InfoContext(ctx, "with span")
+	log.Info("without span")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("got %d records, want 2", len(lines))
+	}
+
+	var with, without map[string]any
+	if err := json.Unmarshal(lines[0], &with); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(lines[1], &without); err != nil {
+		t.Fatal(err)
+	}
+
+	if with["trace_id"] != span.SpanContext().TraceID().String() || with["span_id"] != span.SpanContext().SpanID().String() {
+		t.Errorf("record with span = %v, want trace and span id", with)
+	}
+	if with["op"] != "test" {
+		t.Errorf("WithAttrs lost: %v", with)
+	}
+	if _, ok := without["trace_id"]; ok {
+		t.Errorf("record without span has trace_id: %v", without)
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bookings/internal/logger"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// probeRoutes are polled by the orchestrator and scraper; their access logs
+// are only written at debug level.
+var probeRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// AccessLog puts a logger carrying the request id into the request context,
+// see logger.FromContext, and writes one record per request once it is
+// served. It replaces gin's default logger and must run after RequestID.
+func AccessLog(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		reqLog := log.With(slog.String("request_id", GetRequestID(c)))
+		c.Request = c.Request.WithContext(logger.WithLogger(c.Request.Context(), reqLog))
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := c.Writer.Status()
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case probeRoutes[route]:
+			level = slog.LevelDebug
+		}
+
+		reqLog.LogAttrs(c.Request.Context(), level, "request served",
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+		)
+	}
+}
+
+// Recover turns a panic in a handler into a logged 500 problem response,
+// replacing gin's default recovery which writes to stderr.
+func Recover(log *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		const op = "middleware.Recover"
+
+		ctx := c.Request.Context()
+		logger.FromContext(ctx, log).ErrorContext(ctx, "handler panicked",
+			slog.String("op", op),
+			slog.Any("panic", recovered),
+			slog.String("stack", string(debug.Stack())),
+		)
+
+		p := NewProblem(fmt.Errorf("%s: %v", op, recovered))
+		p.Instance = c.Request.URL.Path
+
+		c.Header("Content-Type", ProblemContentType)
+		c.AbortWithStatusJSON(p.Status, p)
+	})
+}
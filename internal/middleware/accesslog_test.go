@@ -0,0 +1,124 @@
+package middleware_test
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/middleware"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newLoggedRouter(buf *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	r := gin.New()
+	r.Use(middleware.RequestID(), middleware.AccessLog(log), middleware.Recover(log), middleware.Problems(log))
+	r.GET("/hotel/:id", func(c *gin.Context) {
+		logger.FromContext(c.Request.Context(), nil).Info("handler log")
+		c.String(http.StatusOK, "hello")
+	})
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+	return r
+}
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	r := newLoggedRouter(&buf)
+
+	req := httptest.NewRequest(http.MethodGet, "/hotel/7", nil)
+	req.Header.Set(middleware.RequestIDHeader, "abc-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get(middleware.RequestIDHeader); got != "abc-123" {
+		t.Errorf("response request id = %q, want abc-123", got)
+	}
+
+	recs := records(t, &buf)
+	if len(recs) != 2 {
+		t.Fatalf("got %d log records, want handler and access log", len(recs))
+	}
+	for _, rec := range recs {
+		if rec["request_id"] != "abc-123" {
+			t.Errorf("record %v lacks the request id", rec)
+		}
+	}
+
+	access := recs[1]
+	want := map[string]any{"msg": "request served", "method": "GET", "route": "/hotel/:id", "path": "/hotel/7", "status": 200.0, "bytes": 5.0, "level": "INFO"}
+	for k, v := range want {
+		if access[k] != v {
+			t.Errorf("access log %s = %v, want %v", k, access[k], v)
+		}
+	}
+	for _, k := range []string{"latency", "client_ip"} {
+		if _, ok := access[k]; !ok {
+			t.Errorf("access log lacks %s", k)
+		}
+	}
+}
+
+func TestRequestIDGenerated(t *testing.T) {
+	for _, header := range []string{"", "bad id\nInjected: yes", strings.Repeat("a", 200)} {
+		var buf bytes.Buffer
+		r := newLoggedRouter(&buf)
+
+		req := httptest.NewRequest(http.MethodGet, "/hotel/7", nil)
+		if header != "" {
+			req.Header.Set(middleware.RequestIDHeader, header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		got := w.Header().Get(middleware.RequestIDHeader)
+		if len(got) != 32 || got == header {
+			t.Errorf("header %q: request id = %q, want a generated one", header, got)
+		}
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var buf bytes.Buffer
+	r := newLoggedRouter(&buf)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if w.Code != http.StatusInternalServerError || w.Header().Get("Content-Type") != middleware.ProblemContentType {
+		t.Fatalf("got %d %s, want 500 problem", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	var p middleware.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Code != middleware.CodeInternal || p.Detail != "" {
+		t.Errorf("problem = %+v, want internal without detail", p)
+	}
+
+	recs := records(t, &buf)
+	if len(recs) != 2 || recs[0]["panic"] != "boom" || recs[1]["level"] != "ERROR" {
+		t.Errorf("log records = %v, want panic then error access log", recs)
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bookings/internal/logger"
+	"bookings/internal/storage"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. Code is a stable,
+// machine-readable identifier clients can switch on; Type is derived from it.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+
+	Errors []storage.FieldError `json:"errors,omitempty"`
+}
+
+// Problem codes returned to clients.
+const (
+	CodeNotFound    = "not-found"
+	CodeConflict    = "conflict"
+	CodeValidation  = "validation"
+	CodeUnavailable = "unavailable"
+	CodeInternal    = "internal"
+)
+
+// NewProblem maps err onto its problem details. Only messages of domain
+// errors reach the client; anything else is reported without detail.
+func NewProblem(err error) Problem {
+	status, code := http.StatusInternalServerError, CodeInternal
+
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		status, code = http.StatusNotFound, CodeNotFound
+	case errors.Is(err, storage.ErrConflict):
+		status, code = http.StatusConflict, CodeConflict
+	case errors.Is(err, storage.ErrValidation):
+		status, code = http.StatusBadRequest, CodeValidation
+	case errors.Is(err, storage.ErrUnavailable):
+		status, code = http.StatusServiceUnavailable, CodeUnavailable
+	}
+
+	p := Problem{
+		Type:   "/problems/" + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+	}
+
+	var domainErr *storage.Error
+	if errors.As(err, &domainErr) {
+		p.Detail = domainErr.Msg
+		p.Errors = domainErr.Fields
+	}
+
+	return p
+}
+
+// Problems renders the last error a handler attached with c.Error as an
+// application/problem+json response, unless the handler already wrote one.
+func Problems(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "middleware.Problems"
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		p := NewProblem(err)
+		p.Instance = c.Request.URL.Path
+
+		if p.Status >= http.StatusInternalServerError {
+			logger.FromContext(c.Request.Context(), log).ErrorContext(c.Request.Context(), "request failed",
+				slog.String("op", op),
+				slog.String("path", c.FullPath()),
+				logger.Err(err),
+			)
+		}
+
+		if p.Status == http.StatusServiceUnavailable {
+			c.Header("Retry-After", "1")
+		}
+
+		c.Header("Content-Type", ProblemContentType)
+		c.JSON(p.Status, p)
+	}
+}
@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"bookings/internal/middleware"
+	"bookings/internal/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestProblems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"not found", fmt.Errorf("storage.postgres.GetHotel: %w", storage.ErrHotelNotFound), http.StatusNotFound, middleware.CodeNotFound, "hotel not found"},
+		{"conflict", storage.ErrRoomAlreadyBooked, http.StatusConflict, middleware.CodeConflict, "hotel room is already booked for these dates"},
+		{"validation", storage.NewError(storage.ErrValidation, "invalid hotel id"), http.StatusBadRequest, middleware.CodeValidation, "invalid hotel id"},
+		{"unavailable", fmt.Errorf("query failed: %w: %w", storage.ErrUnavailable, errors.New("dial tcp: connection refused")), http.StatusServiceUnavailable, middleware.CodeUnavailable, ""},
+		{"internal", errors.New("ERROR: syntax error at or near \"FROM\""), http.StatusInternalServerError, middleware.CodeInternal, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.Problems(slog.New(slog.NewTextHandler(io.Discard, nil))))
+			r.GET("/hotel/:id", func(c *gin.Context) { c.Error(tt.err) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hotel/7", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != middleware.ProblemContentType {
+				t.Fatalf("content type = %q", ct)
+			}
+
+			var p middleware.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+				t.Fatalf("invalid body %s: %v", w.Body, err)
+			}
+			if p.Status != tt.wantStatus || p.Code != tt.wantCode || p.Detail != tt.wantDetail || p.Instance != "/hotel/7" {
+				t.Fatalf("problem = %+v", p)
+			}
+		})
+	}
+}
+
+func TestProblemsLeavesWrittenResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(middleware.Problems(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	r.GET("/", func(c *gin.Context) {
+		c.Error(storage.ErrHotelNotFound)
+		c.JSON(http.StatusTeapot, gin.H{})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusTeapot {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTeapot)
+	}
+}
@@ -0,0 +1,87 @@
+package middleware_test
+
+import (
+	"bookings/internal/middleware"
+	"bookings/internal/storage"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var handlerSpan trace.SpanContext
+	r := gin.New()
+	r.Use(middleware.Tracing(tp))
+	r.GET("/hotel/:id", func(c *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	r.DELETE("/hotel/:id", func(c *gin.Context) {
+		c.Error(storage.NewError(storage.ErrUnavailable, "database unavailable"))
+		c.Status(http.StatusServiceUnavailable)
+	})
+
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/hotel/42", nil)
+	req.Header.Set("traceparent", parent)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/hotel/42", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	get := spans[0]
+	if get.Name() != "GET /hotel/:id" {
+		t.Errorf("span name = %q, want GET /hotel/:id", get.Name())
+	}
+	if got := get.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace id = %s, want the one from traceparent", got)
+	}
+	if got := get.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span id = %s, want the one from traceparent", got)
+	}
+	if handlerSpan.SpanID() != get.SpanContext().SpanID() {
+		t.Error("handler context does not carry the request span")
+	}
+	if !hasAttr(get.Attributes(), attribute.Key("http.route"), "/hotel/:id") {
+		t.Errorf("missing http.route attribute: %v", get.Attributes())
+	}
+
+	del := spans[1]
+	if del.Parent().IsValid() {
+		t.Error("request without traceparent should start a new trace")
+	}
+	if del.Status().Code != codes.Error {
+		t.Errorf("status = %v, want error for 503", del.Status())
+	}
+	if len(del.Events()) == 0 {
+		t.Error("handler error was not recorded on the span")
+	}
+}
+
+func hasAttr(attrs []attribute.KeyValue, key attribute.Key, value string) bool {
+	for _, a := range attrs {
+		if a.Key == key && a.Value.AsString() == value {
+			return true
+		}
+	}
+	return false
+}
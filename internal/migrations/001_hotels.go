@@ -1,20 +1,21 @@
 package migrations
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
-	"github.com/pressly/goose"
+	"github.com/pressly/goose/v3"
 )
 
 func init() {
-	goose.AddMigration(upHotels, downHotels)
+	goose.AddMigrationContext(upHotels, downHotels)
 }
 
-func upHotels(tx *sql.Tx) error {
+func upHotels(ctx context.Context, tx *sql.Tx) error {
 	const op = "migrations.001_hotel.upHotels"
 
-	_, err := tx.Exec(`
+	_, err := tx.ExecContext(ctx, `
 		CREATE TABLE IF NOT EXISTS hotels(
 			id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
 			country TEXT NOT NULL,
@@ -28,10 +29,10 @@ func upHotels(tx *sql.Tx) error {
 	return nil
 }
 
-func downHotels(tx *sql.Tx) error {
+func downHotels(ctx context.Context, tx *sql.Tx) error {
 	const op = "migrations.001_hotel.downHotels"
 
-	_, err := tx.Exec(`DROP TABLE hotels`)
+	_, err := tx.ExecContext(ctx, `DROP TABLE hotels`)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -1,20 +1,21 @@
 package migrations
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
-	"github.com/pressly/goose"
+	"github.com/pressly/goose/v3"
 )
 
 func init() {
-	goose.AddMigration(upHotelRooms, downHotelRooms)
+	goose.AddMigrationContext(upHotelRooms, downHotelRooms)
 }
 
-func upHotelRooms(tx *sql.Tx) error {
+func upHotelRooms(ctx context.Context, tx *sql.Tx) error {
 	const op = "migrations.001_hotel.upHotelRooms"
 
-	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS hotel_rooms(
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS hotel_rooms(
 	id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
 	hotel_id INTEGER NOT NULL,
 	rooms INTEGER,
@@ -29,10 +30,10 @@ func upHotelRooms(tx *sql.Tx) error {
 	return nil
 }
 
-func downHotelRooms(tx *sql.Tx) error {
+func downHotelRooms(ctx context.Context, tx *sql.Tx) error {
 	const op = "migrations.001_hotel.downHotelRooms"
 
-	_, err := tx.Exec(`DROP TABLE hotel_rooms`)
+	_, err := tx.ExecContext(ctx, `DROP TABLE hotel_rooms`)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -1,20 +1,21 @@
 package migrations
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
-	"github.com/pressly/goose"
+	"github.com/pressly/goose/v3"
 )
 
 func init() {
-	goose.AddMigration(upVisitors, downVisitors)
+	goose.AddMigrationContext(upVisitors, downVisitors)
 }
 
-func upVisitors(tx *sql.Tx) error {
+func upVisitors(ctx context.Context, tx *sql.Tx) error {
 	const op = "migrations.001_hotel.upVisitors"
 
-	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS visitors(
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS visitors(
 	id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
 	hotel_id INTEGER NOT NULL,
 	hotel_room_id INTEGER NOT NULL,
@@ -28,10 +29,10 @@ func upVisitors(tx *sql.Tx) error {
 	return nil
 }
 
-func downVisitors(tx *sql.Tx) error {
+func downVisitors(ctx context.Context, tx *sql.Tx) error {
 	const op = "migrations.001_hotel.downVisitors"
 
-	_, err := tx.Exec(`DROP TABLE visitors`)
+	_, err := tx.ExecContext(ctx, `DROP TABLE visitors`)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upReservations, downReservations)
+}
+
+func upReservations(ctx context.Context, tx *sql.Tx) error {
+	const op = "migrations.004_reservations.upReservations"
+
+	// btree_gist lets the exclusion constraint compare hotel_room_id with "=".
+	_, err := tx.ExecContext(ctx, `CREATE EXTENSION IF NOT EXISTS btree_gist`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS reservations(
+	id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	hotel_room_id INTEGER NOT NULL,
+	visitor_id INTEGER NOT NULL,
+	check_in DATE NOT NULL,
+	check_out DATE NOT NULL,
+	guests INTEGER NOT NULL CHECK (guests > 0),
+	status TEXT NOT NULL DEFAULT 'pending'
+		CHECK (status IN ('pending', 'confirmed', 'cancelled', 'checked_in', 'checked_out')),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CONSTRAINT reservations_dates_check CHECK (check_out > check_in),
+	CONSTRAINT reservations_no_overlap EXCLUDE USING gist (
+		hotel_room_id WITH =,
+		daterange(check_in, check_out) WITH &&
+	) WHERE (status <> 'cancelled'),
+	CONSTRAINT reservations_hotel_room_id_fkey FOREIGN KEY (hotel_room_id) REFERENCES hotel_rooms(id),
+	CONSTRAINT reservations_visitor_id_fkey FOREIGN KEY (visitor_id) REFERENCES visitors(id))`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `ALTER TABLE hotel_rooms DROP COLUMN IF EXISTS busy`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func downReservations(ctx context.Context, tx *sql.Tx) error {
+	const op = "migrations.004_reservations.downReservations"
+
+	_, err := tx.ExecContext(ctx, `ALTER TABLE hotel_rooms ADD COLUMN IF NOT EXISTS busy BOOLEAN`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DROP TABLE reservations`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
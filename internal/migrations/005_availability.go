@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAvailability, downAvailability)
+}
+
+// upAvailability adds room capacity and the indexes the availability search
+// relies on. Overlap checks use the gist index behind reservations_no_overlap.
+func upAvailability(ctx context.Context, tx *sql.Tx) error {
+	const op = "migrations.005_availability.upAvailability"
+
+	_, err := tx.ExecContext(ctx, `ALTER TABLE hotel_rooms
+	ADD COLUMN IF NOT EXISTS max_guests INTEGER NOT NULL DEFAULT 2 CHECK (max_guests > 0)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS hotels_location_idx ON hotels (lower(country), lower(city), stars)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS hotels_city_idx ON hotels (lower(city))`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS hotel_rooms_hotel_id_max_guests_idx ON hotel_rooms (hotel_id, max_guests)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func downAvailability(ctx context.Context, tx *sql.Tx) error {
+	const op = "migrations.005_availability.downAvailability"
+
+	_, err := tx.ExecContext(ctx, `DROP INDEX IF EXISTS hotel_rooms_hotel_id_max_guests_idx`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DROP INDEX IF EXISTS hotels_city_idx`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DROP INDEX IF EXISTS hotels_location_idx`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `ALTER TABLE hotel_rooms DROP COLUMN IF EXISTS max_guests`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+-- Keyset pagination of the list endpoints orders by (sort column, id); hotel
+-- names are also searched by case-insensitive prefix.
+
+-- +goose Up
+CREATE INDEX IF NOT EXISTS hotels_stars_id_idx ON hotels (stars, id);
+CREATE INDEX IF NOT EXISTS hotels_name_prefix_idx ON hotels (lower(hotel_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS visitors_last_name_id_idx ON visitors (last_name, id);
+CREATE INDEX IF NOT EXISTS reservations_check_in_id_idx ON reservations (check_in, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS reservations_check_in_id_idx;
+DROP INDEX IF EXISTS visitors_last_name_id_idx;
+DROP INDEX IF EXISTS hotels_name_prefix_idx;
+DROP INDEX IF EXISTS hotels_stars_id_idx;
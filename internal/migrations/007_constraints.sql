@@ -0,0 +1,86 @@
+-- Consistency constraints between hotels, rooms, visitors and reservations.
+--
+-- The rooms column becomes the room number and is unique within a hotel. It
+-- used to hold an optional room count, so existing rooms are numbered 1, 2, ...
+-- within their hotel in the order they were created. A visitor's hotel_id is
+-- tied to the hotel of their room by a composite foreign key, so moving a room
+-- to another hotel moves its visitors along. A hotel cannot be deleted while
+-- it still has rooms.
+--
+-- hotel_rooms.hotel_id lookups use the two unique constraints below, which
+-- both lead with hotel_id.
+
+-- +goose Up
+UPDATE hotel_rooms SET meals = false WHERE meals IS NULL;
+UPDATE hotel_rooms SET bar = false WHERE bar IS NULL;
+UPDATE hotel_rooms SET service = false WHERE service IS NULL;
+
+UPDATE hotel_rooms hr SET rooms = numbered.number
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY hotel_id ORDER BY id) AS number
+    FROM hotel_rooms
+) numbered
+WHERE hr.id = numbered.id;
+
+ALTER TABLE hotel_rooms
+    ALTER COLUMN rooms SET NOT NULL,
+    ALTER COLUMN meals SET DEFAULT false,
+    ALTER COLUMN meals SET NOT NULL,
+    ALTER COLUMN bar SET DEFAULT false,
+    ALTER COLUMN bar SET NOT NULL,
+    ALTER COLUMN service SET DEFAULT false,
+    ALTER COLUMN service SET NOT NULL,
+    ADD CONSTRAINT hotel_rooms_rooms_check CHECK (rooms > 0),
+    ADD CONSTRAINT hotel_rooms_hotel_id_rooms_key UNIQUE (hotel_id, rooms),
+    ADD CONSTRAINT hotel_rooms_hotel_id_id_key UNIQUE (hotel_id, id),
+    DROP CONSTRAINT hotel_rooms_hotel_id_fkey,
+    ADD CONSTRAINT hotel_rooms_hotel_id_fkey FOREIGN KEY (hotel_id) REFERENCES hotels (id) ON DELETE RESTRICT;
+
+-- The room is the source of truth for visitors recorded against the wrong hotel.
+UPDATE visitors v SET hotel_id = hr.hotel_id
+FROM hotel_rooms hr
+WHERE hr.id = v.hotel_room_id AND v.hotel_id <> hr.hotel_id;
+
+ALTER TABLE visitors
+    DROP CONSTRAINT visitors_hotel_room_id_fkey,
+    ADD CONSTRAINT visitors_hotel_room_fkey FOREIGN KEY (hotel_id, hotel_room_id)
+        REFERENCES hotel_rooms (hotel_id, id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS visitors_hotel_room_id_idx ON visitors (hotel_room_id, hotel_id);
+
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_hotel_room_id_fkey,
+    ADD CONSTRAINT reservations_hotel_room_id_fkey FOREIGN KEY (hotel_room_id) REFERENCES hotel_rooms (id) ON DELETE RESTRICT,
+    DROP CONSTRAINT reservations_visitor_id_fkey,
+    ADD CONSTRAINT reservations_visitor_id_fkey FOREIGN KEY (visitor_id) REFERENCES visitors (id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS reservations_visitor_id_idx ON reservations (visitor_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS reservations_visitor_id_idx;
+
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_visitor_id_fkey,
+    ADD CONSTRAINT reservations_visitor_id_fkey FOREIGN KEY (visitor_id) REFERENCES visitors (id),
+    DROP CONSTRAINT reservations_hotel_room_id_fkey,
+    ADD CONSTRAINT reservations_hotel_room_id_fkey FOREIGN KEY (hotel_room_id) REFERENCES hotel_rooms (id);
+
+DROP INDEX IF EXISTS visitors_hotel_room_id_idx;
+
+ALTER TABLE visitors
+    DROP CONSTRAINT visitors_hotel_room_fkey,
+    ADD CONSTRAINT visitors_hotel_room_id_fkey FOREIGN KEY (hotel_room_id) REFERENCES hotel_rooms (id);
+
+ALTER TABLE hotel_rooms
+    DROP CONSTRAINT hotel_rooms_hotel_id_fkey,
+    ADD CONSTRAINT hotel_rooms_hotel_id_fkey FOREIGN KEY (hotel_id) REFERENCES hotels (id),
+    DROP CONSTRAINT hotel_rooms_hotel_id_id_key,
+    DROP CONSTRAINT hotel_rooms_hotel_id_rooms_key,
+    DROP CONSTRAINT hotel_rooms_rooms_check,
+    ALTER COLUMN service DROP NOT NULL,
+    ALTER COLUMN service DROP DEFAULT,
+    ALTER COLUMN bar DROP NOT NULL,
+    ALTER COLUMN bar DROP DEFAULT,
+    ALTER COLUMN meals DROP NOT NULL,
+    ALTER COLUMN meals DROP DEFAULT,
+    ALTER COLUMN rooms DROP NOT NULL;
@@ -0,0 +1,118 @@
+-- Separates the sellable product (room_types) from physical inventory
+-- (hotel_rooms). Reservations book a room type; the physical room is assigned
+-- at check-in.
+--
+-- Existing rooms are converted into one room type per hotel and combination
+-- of max_guests, meals, bar and service. Floors are unknown and start at 0.
+-- Existing reservations keep the room they were made for.
+
+-- +goose Up
+CREATE TABLE room_types (
+    id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    hotel_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    max_adults INTEGER NOT NULL CHECK (max_adults > 0),
+    max_children INTEGER NOT NULL DEFAULT 0 CHECK (max_children >= 0),
+    bed_configuration TEXT NOT NULL DEFAULT '',
+    base_occupancy INTEGER NOT NULL,
+    size_sqm INTEGER NOT NULL DEFAULT 0 CHECK (size_sqm >= 0),
+    meals BOOLEAN NOT NULL DEFAULT false,
+    bar BOOLEAN NOT NULL DEFAULT false,
+    service BOOLEAN NOT NULL DEFAULT false,
+    CONSTRAINT room_types_base_occupancy_check CHECK (base_occupancy BETWEEN 1 AND max_adults + max_children),
+    CONSTRAINT room_types_hotel_id_name_key UNIQUE (hotel_id, name),
+    CONSTRAINT room_types_hotel_id_id_key UNIQUE (hotel_id, id),
+    CONSTRAINT room_types_hotel_id_fkey FOREIGN KEY (hotel_id) REFERENCES hotels (id) ON DELETE RESTRICT
+);
+
+INSERT INTO room_types (hotel_id, name, max_adults, base_occupancy, meals, bar, service)
+SELECT DISTINCT hotel_id,
+    max_guests || ' guests'
+        || CASE WHEN meals THEN ', meals' ELSE '' END
+        || CASE WHEN bar THEN ', bar' ELSE '' END
+        || CASE WHEN service THEN ', service' ELSE '' END,
+    max_guests, max_guests, meals, bar, service
+FROM hotel_rooms;
+
+ALTER TABLE hotel_rooms
+    ADD COLUMN room_type_id INTEGER,
+    ADD COLUMN floor INTEGER NOT NULL DEFAULT 0;
+
+UPDATE hotel_rooms hr SET room_type_id = rt.id
+FROM room_types rt
+WHERE rt.hotel_id = hr.hotel_id
+  AND rt.max_adults = hr.max_guests
+  AND rt.meals = hr.meals AND rt.bar = hr.bar AND rt.service = hr.service;
+
+-- A room's type belongs to the same hotel. The (room_type_id, id) key lets
+-- reservations check that the assigned room is of the booked type.
+ALTER TABLE hotel_rooms
+    ALTER COLUMN room_type_id SET NOT NULL,
+    ADD CONSTRAINT hotel_rooms_room_type_fkey FOREIGN KEY (hotel_id, room_type_id) REFERENCES room_types (hotel_id, id),
+    ADD CONSTRAINT hotel_rooms_room_type_id_id_key UNIQUE (room_type_id, id),
+    DROP COLUMN max_guests,
+    DROP COLUMN meals,
+    DROP COLUMN bar,
+    DROP COLUMN service;
+
+ALTER TABLE hotel_rooms RENAME COLUMN rooms TO room_number;
+ALTER TABLE hotel_rooms RENAME CONSTRAINT hotel_rooms_rooms_check TO hotel_rooms_room_number_check;
+ALTER TABLE hotel_rooms RENAME CONSTRAINT hotel_rooms_hotel_id_rooms_key TO hotel_rooms_hotel_id_room_number_key;
+
+ALTER TABLE reservations ADD COLUMN room_type_id INTEGER;
+
+UPDATE reservations r SET room_type_id = hr.room_type_id
+FROM hotel_rooms hr
+WHERE hr.id = r.hotel_room_id;
+
+ALTER TABLE reservations
+    ALTER COLUMN room_type_id SET NOT NULL,
+    ALTER COLUMN hotel_room_id DROP NOT NULL,
+    ADD CONSTRAINT reservations_room_type_id_fkey FOREIGN KEY (room_type_id) REFERENCES room_types (id),
+    DROP CONSTRAINT reservations_hotel_room_id_fkey,
+    ADD CONSTRAINT reservations_hotel_room_fkey FOREIGN KEY (room_type_id, hotel_room_id)
+        REFERENCES hotel_rooms (room_type_id, id) ON DELETE RESTRICT;
+
+-- Type-level availability counts the reservations of a type night by night.
+CREATE INDEX IF NOT EXISTS reservations_room_type_id_stay_idx ON reservations (room_type_id, check_in, check_out)
+    WHERE status <> 'cancelled';
+
+-- +goose Down
+-- Reservations that were never assigned a room cannot be represented by the
+-- old schema and are deleted.
+DROP INDEX IF EXISTS reservations_room_type_id_stay_idx;
+
+DELETE FROM reservations WHERE hotel_room_id IS NULL;
+
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_hotel_room_fkey,
+    ADD CONSTRAINT reservations_hotel_room_id_fkey FOREIGN KEY (hotel_room_id) REFERENCES hotel_rooms (id) ON DELETE RESTRICT,
+    DROP CONSTRAINT reservations_room_type_id_fkey,
+    ALTER COLUMN hotel_room_id SET NOT NULL,
+    DROP COLUMN room_type_id;
+
+ALTER TABLE hotel_rooms RENAME CONSTRAINT hotel_rooms_hotel_id_room_number_key TO hotel_rooms_hotel_id_rooms_key;
+ALTER TABLE hotel_rooms RENAME CONSTRAINT hotel_rooms_room_number_check TO hotel_rooms_rooms_check;
+ALTER TABLE hotel_rooms RENAME COLUMN room_number TO rooms;
+
+ALTER TABLE hotel_rooms
+    ADD COLUMN max_guests INTEGER NOT NULL DEFAULT 2 CHECK (max_guests > 0),
+    ADD COLUMN meals BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN bar BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN service BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE hotel_rooms hr
+SET max_guests = rt.max_adults + rt.max_children, meals = rt.meals, bar = rt.bar, service = rt.service
+FROM room_types rt
+WHERE rt.id = hr.room_type_id;
+
+CREATE INDEX IF NOT EXISTS hotel_rooms_hotel_id_max_guests_idx ON hotel_rooms (hotel_id, max_guests);
+
+ALTER TABLE hotel_rooms
+    DROP CONSTRAINT hotel_rooms_room_type_id_id_key,
+    DROP CONSTRAINT hotel_rooms_room_type_fkey,
+    DROP COLUMN floor,
+    DROP COLUMN room_type_id;
+
+DROP TABLE room_types;
@@ -0,0 +1,74 @@
+-- Replaces the meals, bar and service flags of room types with an amenity
+-- catalog that hotels and room types link to. Labels map a locale to the
+-- display name, e.g. {"en": "Wi-Fi", "de": "WLAN"}.
+
+-- +goose Up
+CREATE TABLE amenities (
+    code TEXT PRIMARY KEY,
+    category TEXT NOT NULL,
+    labels JSONB NOT NULL DEFAULT '{}',
+    CONSTRAINT amenities_code_check CHECK (code ~ '^[a-z][a-z0-9_]{0,49}$'),
+    CONSTRAINT amenities_category_check CHECK (category <> ''),
+    CONSTRAINT amenities_labels_check CHECK (jsonb_typeof(labels) = 'object')
+);
+
+CREATE TABLE hotel_amenities (
+    hotel_id INTEGER NOT NULL,
+    amenity_code TEXT NOT NULL,
+    PRIMARY KEY (hotel_id, amenity_code),
+    CONSTRAINT hotel_amenities_hotel_id_fkey FOREIGN KEY (hotel_id) REFERENCES hotels (id) ON DELETE CASCADE,
+    CONSTRAINT hotel_amenities_amenity_code_fkey FOREIGN KEY (amenity_code) REFERENCES amenities (code) ON DELETE RESTRICT
+);
+
+CREATE TABLE room_type_amenities (
+    room_type_id INTEGER NOT NULL,
+    amenity_code TEXT NOT NULL,
+    PRIMARY KEY (room_type_id, amenity_code),
+    CONSTRAINT room_type_amenities_room_type_id_fkey FOREIGN KEY (room_type_id) REFERENCES room_types (id) ON DELETE CASCADE,
+    CONSTRAINT room_type_amenities_amenity_code_fkey FOREIGN KEY (amenity_code) REFERENCES amenities (code) ON DELETE RESTRICT
+);
+
+-- Filtering by amenity and deleting one look links up by code.
+CREATE INDEX hotel_amenities_amenity_code_idx ON hotel_amenities (amenity_code);
+CREATE INDEX room_type_amenities_amenity_code_idx ON room_type_amenities (amenity_code);
+
+INSERT INTO amenities (code, category, labels) VALUES
+    ('wifi', 'connectivity', '{"en": "Wi-Fi", "de": "WLAN"}'),
+    ('parking', 'transport', '{"en": "Parking", "de": "Parkplatz"}'),
+    ('pool', 'wellness', '{"en": "Swimming pool", "de": "Schwimmbad"}'),
+    ('spa', 'wellness', '{"en": "Spa", "de": "Spa"}'),
+    ('gym', 'wellness', '{"en": "Fitness room", "de": "Fitnessraum"}'),
+    ('pets', 'policies', '{"en": "Pets allowed", "de": "Haustiere erlaubt"}'),
+    ('accessibility', 'accessibility', '{"en": "Wheelchair accessible", "de": "Rollstuhlgerecht"}'),
+    ('air_conditioning', 'room', '{"en": "Air conditioning", "de": "Klimaanlage"}'),
+    ('meals', 'food', '{"en": "Meals included", "de": "Mahlzeiten inklusive"}'),
+    ('bar', 'food', '{"en": "Bar", "de": "Bar"}'),
+    ('room_service', 'service', '{"en": "Room service", "de": "Zimmerservice"}');
+
+INSERT INTO room_type_amenities (room_type_id, amenity_code)
+SELECT id, 'meals' FROM room_types WHERE meals
+UNION ALL
+SELECT id, 'bar' FROM room_types WHERE bar
+UNION ALL
+SELECT id, 'room_service' FROM room_types WHERE service;
+
+ALTER TABLE room_types
+    DROP COLUMN meals,
+    DROP COLUMN bar,
+    DROP COLUMN service;
+
+-- +goose Down
+-- Amenities other than meals, bar and room_service are lost.
+ALTER TABLE room_types
+    ADD COLUMN meals BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN bar BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN service BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE room_types rt SET
+    meals = EXISTS (SELECT 1 FROM room_type_amenities WHERE room_type_id = rt.id AND amenity_code = 'meals'),
+    bar = EXISTS (SELECT 1 FROM room_type_amenities WHERE room_type_id = rt.id AND amenity_code = 'bar'),
+    service = EXISTS (SELECT 1 FROM room_type_amenities WHERE room_type_id = rt.id AND amenity_code = 'room_service');
+
+DROP TABLE room_type_amenities;
+DROP TABLE hotel_amenities;
+DROP TABLE amenities;
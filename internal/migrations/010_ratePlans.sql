@@ -0,0 +1,72 @@
+-- Rate plans price the nights of a room type. Seasons override the nightly
+-- prices for an inclusive date range and never overlap within a plan; stay
+-- discounts take a percentage off stays of a minimum length. Amounts are in
+-- minor units of the plan's currency.
+--
+-- Reservations remember the plan they were priced with and the quoted total.
+-- Reservations made before rate plans existed have neither.
+
+-- +goose Up
+CREATE TABLE rate_plans (
+    id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    room_type_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    currency TEXT NOT NULL,
+    base_price_cents BIGINT NOT NULL,
+    weekend_price_cents BIGINT NOT NULL DEFAULT 0,
+    extra_guest_price_cents BIGINT NOT NULL DEFAULT 0,
+    CONSTRAINT rate_plans_currency_check CHECK (currency ~ '^[A-Z]{3}$'),
+    CONSTRAINT rate_plans_prices_check CHECK (
+        base_price_cents > 0 AND weekend_price_cents >= 0 AND extra_guest_price_cents >= 0
+    ),
+    CONSTRAINT rate_plans_room_type_id_name_key UNIQUE (room_type_id, name),
+    CONSTRAINT rate_plans_room_type_id_id_key UNIQUE (room_type_id, id),
+    CONSTRAINT rate_plans_room_type_id_fkey FOREIGN KEY (room_type_id) REFERENCES room_types (id) ON DELETE CASCADE
+);
+
+CREATE TABLE rate_plan_seasons (
+    rate_plan_id INTEGER NOT NULL,
+    from_date DATE NOT NULL,
+    to_date DATE NOT NULL,
+    price_cents BIGINT NOT NULL,
+    weekend_price_cents BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (rate_plan_id, from_date),
+    CONSTRAINT rate_plan_seasons_dates_check CHECK (to_date >= from_date),
+    CONSTRAINT rate_plan_seasons_prices_check CHECK (price_cents > 0 AND weekend_price_cents >= 0),
+    CONSTRAINT rate_plan_seasons_no_overlap EXCLUDE USING gist (
+        rate_plan_id WITH =,
+        daterange(from_date, to_date, '[]') WITH &&
+    ),
+    CONSTRAINT rate_plan_seasons_rate_plan_id_fkey FOREIGN KEY (rate_plan_id) REFERENCES rate_plans (id) ON DELETE CASCADE
+);
+
+CREATE TABLE rate_plan_discounts (
+    rate_plan_id INTEGER NOT NULL,
+    min_nights INTEGER NOT NULL,
+    percent INTEGER NOT NULL,
+    PRIMARY KEY (rate_plan_id, min_nights),
+    CONSTRAINT rate_plan_discounts_min_nights_check CHECK (min_nights >= 2),
+    CONSTRAINT rate_plan_discounts_percent_check CHECK (percent BETWEEN 1 AND 100),
+    CONSTRAINT rate_plan_discounts_rate_plan_id_fkey FOREIGN KEY (rate_plan_id) REFERENCES rate_plans (id) ON DELETE CASCADE
+);
+
+-- The plan of a reservation prices the reserved room type.
+ALTER TABLE reservations
+    ADD COLUMN rate_plan_id INTEGER,
+    ADD COLUMN currency TEXT NOT NULL DEFAULT '',
+    ADD COLUMN total_cents BIGINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT reservations_rate_plan_fkey FOREIGN KEY (room_type_id, rate_plan_id)
+        REFERENCES rate_plans (room_type_id, id) ON DELETE RESTRICT;
+
+CREATE INDEX reservations_rate_plan_id_idx ON reservations (rate_plan_id) WHERE rate_plan_id IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_rate_plan_fkey,
+    DROP COLUMN total_cents,
+    DROP COLUMN currency,
+    DROP COLUMN rate_plan_id;
+
+DROP TABLE rate_plan_discounts;
+DROP TABLE rate_plan_seasons;
+DROP TABLE rate_plans;
@@ -0,0 +1,24 @@
+-- Stay restrictions limit what can be booked of a room type around a date.
+-- Minimum and maximum stay and closed to arrival apply to stays arriving on
+-- the date, closed to departure to stays leaving on it and stop-sell to
+-- every stay that spends the night. A zero length does not restrict; rows
+-- that restrict nothing are removed rather than kept.
+
+-- +goose Up
+CREATE TABLE stay_restrictions (
+    room_type_id INTEGER NOT NULL,
+    day DATE NOT NULL,
+    min_stay INTEGER NOT NULL DEFAULT 0,
+    max_stay INTEGER NOT NULL DEFAULT 0,
+    closed_to_arrival BOOLEAN NOT NULL DEFAULT false,
+    closed_to_departure BOOLEAN NOT NULL DEFAULT false,
+    stop_sell BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (room_type_id, day),
+    CONSTRAINT stay_restrictions_stay_check CHECK (
+        min_stay >= 0 AND max_stay >= 0 AND (max_stay = 0 OR max_stay >= min_stay)
+    ),
+    CONSTRAINT stay_restrictions_room_type_id_fkey FOREIGN KEY (room_type_id) REFERENCES room_types (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE stay_restrictions;
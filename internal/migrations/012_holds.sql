@@ -0,0 +1,27 @@
+-- A hold reserves a room type for a guest who is checking out. It counts
+-- against availability until expires_at, after which it is dead even before
+-- the sweeper marks it expired. Confirming a hold makes it a booking and
+-- clears the expiry.
+
+-- +goose Up
+ALTER TABLE reservations
+    ADD COLUMN expires_at TIMESTAMPTZ,
+    DROP CONSTRAINT reservations_status_check,
+    ADD CONSTRAINT reservations_status_check
+        CHECK (status IN ('held', 'expired', 'pending', 'confirmed', 'cancelled', 'checked_in', 'checked_out')),
+    ADD CONSTRAINT reservations_hold_expiry_check CHECK (status <> 'held' OR expires_at IS NOT NULL);
+
+-- The sweeper looks for the holds that have run out.
+CREATE INDEX reservations_held_expires_at_idx ON reservations (expires_at) WHERE status = 'held';
+
+-- +goose Down
+DROP INDEX reservations_held_expires_at_idx;
+
+UPDATE reservations SET status = 'cancelled' WHERE status IN ('held', 'expired');
+
+ALTER TABLE reservations
+    DROP CONSTRAINT reservations_hold_expiry_check,
+    DROP CONSTRAINT reservations_status_check,
+    ADD CONSTRAINT reservations_status_check
+        CHECK (status IN ('pending', 'confirmed', 'cancelled', 'checked_in', 'checked_out')),
+    DROP COLUMN expires_at;
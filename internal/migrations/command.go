@@ -0,0 +1,165 @@
+package migrations
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	stdlog "log"
+	"net/url"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+// Usage describes the migrate subcommand.
+const Usage = `usage: bookings [config flags] migrate <command> [flags] [args]
+
+commands:
+  up                  apply all pending migrations
+  up-to VERSION       apply pending migrations up to VERSION
+  down                roll back the latest migration (destructive)
+  down-to VERSION     roll back to VERSION, 0 drops everything (destructive)
+  redo                roll back and reapply the latest migration (destructive)
+  reset               roll back every migration (destructive)
+  status              list applied and pending migrations
+  version             print the database version
+  create NAME [sql|go] write a new migration file to --dir
+
+flags:
+`
+
+// destructive lists the commands that drop schema or data.
+var destructive = map[string]bool{
+	"down":    true,
+	"down-to": true,
+	"redo":    true,
+	"reset":   true,
+}
+
+// ErrNotConfirmed is returned when a destructive command is not confirmed.
+var ErrNotConfirmed = errors.New("destructive migration not confirmed")
+
+// Command runs the migrate subcommand against databaseURL. Destructive
+// commands need --destructive and the database name, either through
+// --confirm or typed at the prompt on in.
+func Command(ctx context.Context, databaseURL string, args []string, in io.Reader, out io.Writer) error {
+	const op = "migrations.Command"
+
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() {
+		fmt.Fprint(out, Usage)
+		fs.PrintDefaults()
+	}
+	sourceDir := fs.String("dir", "internal/migrations", "directory create writes new migrations to")
+	allowDestructive := fs.Bool("destructive", false, "allow commands that roll back migrations")
+	confirm := fs.String("confirm", "", "database name, skips the confirmation prompt")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return fmt.Errorf("%s: missing command", op)
+	}
+	command, rest := positional[0], positional[1:]
+
+	goose.SetLogger(stdlog.New(out, "", 0))
+
+	switch command {
+	case "create":
+		if len(rest) == 0 || len(rest) > 2 {
+			return fmt.Errorf("%s: usage: create NAME [sql|go]", op)
+		}
+		migrationType := "sql"
+		if len(rest) == 2 {
+			migrationType = rest[1]
+		}
+		if migrationType != "sql" && migrationType != "go" {
+			return fmt.Errorf("%s: migration type must be sql or go, got %q", op, migrationType)
+		}
+		if err := goose.Create(nil, *sourceDir, rest[0], migrationType); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	case "up", "up-to", "status", "version":
+	default:
+		if !destructive[command] {
+			fs.Usage()
+			return fmt.Errorf("%s: unknown command %q", op, command)
+		}
+		if !*allowDestructive {
+			return fmt.Errorf("%s: %s rolls back migrations, rerun with --destructive", op, command)
+		}
+		if err := confirmDestructive(command, databaseName(databaseURL), *confirm, in, out); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	db, err := Open(databaseURL)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := goose.RunContext(ctx, command, db, dir, rest...); err != nil {
+		return fmt.Errorf("%s: %s: %w", op, command, err)
+	}
+
+	return nil
+}
+
+// confirmDestructive accepts name given through --confirm or typed at the
+// prompt, so a rollback is never run against the wrong database by accident.
+// Without a name there is nothing to confirm, so it refuses.
+func confirmDestructive(command, name, confirm string, in io.Reader, out io.Writer) error {
+	if name == "" {
+		return fmt.Errorf("%w: the database URL names no database, put it in the URL path, e.g. postgres://host/bookings", ErrNotConfirmed)
+	}
+
+	if confirm == "" {
+		fmt.Fprintf(out, "%s will drop schema and data in database %q.\nType the database name to continue: ", command, name)
+
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		confirm = strings.TrimSpace(line)
+	}
+
+	if confirm != name {
+		return ErrNotConfirmed
+	}
+
+	return nil
+}
+
+// databaseName returns the database part of a postgres URL, or "" when
+// databaseURL is not a URL, such as a "host=... dbname=..." string.
+func databaseName(databaseURL string) string {
+	u, err := url.Parse(databaseURL)
+	if err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		return ""
+	}
+	return strings.TrimPrefix(u.Path, "/")
+}
+
+// parseInterspersed parses flags given before, between or after the
+// positional arguments, so "down-to 3 --destructive" works as expected.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
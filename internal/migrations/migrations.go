@@ -0,0 +1,90 @@
+// Package migrations holds the database schema. SQL migrations are embedded
+// in the binary; Go migrations register themselves with goose in init.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed *.sql
+var embedded embed.FS
+
+// dir is the embedded directory goose reads migrations from.
+const dir = "."
+
+func init() {
+	goose.SetBaseFS(embedded)
+	goose.SetSequential(true)
+	if err := goose.SetDialect("postgres"); err != nil {
+		panic(err)
+	}
+}
+
+// Open opens a database/sql handle for goose, which does not work with a
+// pgx pool.
+func Open(databaseURL string) (*sql.DB, error) {
+	const op = "migrations.Open"
+
+	db, err := sql.Open("pgx", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return db, nil
+}
+
+// Up applies every pending migration.
+func Up(ctx context.Context, db *sql.DB) error {
+	const op = "migrations.Up"
+
+	if err := goose.UpContext(ctx, db, dir); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Pending returns the number of migrations newer than the database version.
+func Pending(ctx context.Context, db *sql.DB) (int, error) {
+	const op = "migrations.Pending"
+
+	current, err := goose.GetDBVersionContext(ctx, db)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pending, err := goose.CollectMigrations(dir, current, goose.MaxVersion)
+	if errors.Is(err, goose.ErrNoMigrationFiles) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(pending), nil
+}
+
+// Latest returns the version of the newest migration, which is the version a
+// fully migrated database reports.
+func Latest() (int64, error) {
+	const op = "migrations.Latest"
+
+	all, err := goose.CollectMigrations(dir, 0, goose.MaxVersion)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	last, err := all.Last()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return last.Version, nil
+}
@@ -0,0 +1,127 @@
+package migrations_test
+
+import (
+	"bookings/internal/migrations"
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+)
+
+// baselineVersion is the schema version the service shipped with before
+// migrations were managed.
+const baselineVersion = 3
+
+// TestUpFromBaseline runs against BOOKINGS_TEST_DATABASE_URL. It migrates
+// data written by the baseline schema in a scratch schema of its own, which
+// it drops again.
+func TestUpFromBaseline(t *testing.T) {
+	dbURL := os.Getenv("BOOKINGS_TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("BOOKINGS_TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+
+	admin, err := migrations.Open(dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer admin.Close()
+
+	// The extension stays in public, shared with the other tests of the
+	// database, so dropping the scratch schema does not drop it.
+	for _, stmt := range []string{
+		`CREATE EXTENSION IF NOT EXISTS btree_gist SCHEMA public`,
+		`DROP SCHEMA IF EXISTS migrations_baseline CASCADE`,
+		`CREATE SCHEMA migrations_baseline`,
+	} {
+		if _, err := admin.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	t.Cleanup(func() {
+		admin.ExecContext(context.Background(), `DROP SCHEMA IF EXISTS migrations_baseline CASCADE`)
+	})
+
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	q.Set("search_path", "migrations_baseline,public")
+	u.RawQuery = q.Encode()
+
+	db, err := migrations.Open(u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// One connection keeps every statement on the scratch search_path.
+	db.SetMaxOpenConns(1)
+
+	if err := goose.UpToContext(ctx, db, ".", baselineVersion); err != nil {
+		t.Fatalf("migrate to baseline: %v", err)
+	}
+
+	// Baseline rooms hold an optional room count, which repeats within a
+	// hotel and may be missing or zero.
+	exec(t, db, `INSERT INTO hotels (country, city, hotel_name, stars) VALUES
+		('Austria', 'Vienna', 'Sacher', 5), ('Austria', 'Graz', 'Wiesler', 4)`)
+	exec(t, db, `INSERT INTO hotel_rooms (hotel_id, rooms, meals, bar, service, busy) VALUES
+		(1, NULL, NULL, true, NULL, false),
+		(1, 0, true, NULL, NULL, NULL),
+		(1, 2, false, false, false, true),
+		(2, 2, NULL, NULL, NULL, NULL),
+		(1, 2, true, true, true, false)`)
+	exec(t, db, `INSERT INTO visitors (hotel_id, hotel_room_id, first_name, last_name, age) VALUES
+		(1, 3, 'Anna', 'Berg', 30), (1, 4, 'Jan', 'Novak', 40)`)
+
+	if err := migrations.Up(ctx, db); err != nil {
+		t.Fatalf("migrate baseline data: %v", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT hotel_id, room_number FROM hotel_rooms ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var got [][2]int
+	for rows.Next() {
+		var room [2]int
+		if err := rows.Scan(&room[0], &room[1]); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, room)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][2]int{{1, 1}, {1, 2}, {1, 3}, {2, 1}, {1, 4}}
+	if !slices.Equal(got, want) {
+		t.Errorf("hotel rooms (hotel, number) = %v, want %v", got, want)
+	}
+
+	// The visitor recorded against the wrong hotel follows their room.
+	var hotelId int
+	err = db.QueryRowContext(ctx, `SELECT hotel_id FROM visitors WHERE hotel_room_id = 4`).Scan(&hotelId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hotelId != 2 {
+		t.Errorf("visitor hotel_id = %d, want 2", hotelId)
+	}
+}
+
+func exec(t *testing.T, db *sql.DB, query string) {
+	t.Helper()
+
+	if _, err := db.ExecContext(context.Background(), query); err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+}
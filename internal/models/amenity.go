@@ -0,0 +1,10 @@
+package models
+
+// Amenity is an entry of the amenity catalog that hotels and room types link
+// to. Code is a lower-case identifier such as "wifi"; Labels maps a locale to
+// the display name.
+type Amenity struct {
+	Code     string            `json:"code" binding:"required,max=50"`
+	Category string            `json:"category" binding:"required,max=50"`
+	Labels   map[string]string `json:"labels" binding:"dive,keys,min=2,max=35,endkeys,required,max=100"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// HotelAvailability is a hotel together with the room types that are free for
+// the requested stay.
+type HotelAvailability struct {
+	Hotel
+	RoomTypes []RoomTypeAvailability `json:"room_types"`
+}
+
+// RoomTypeAvailability is a room type and the number of its rooms that are
+// free on every night of the stay. Quote is the cheapest price of the stay,
+// or nil when the type has no rate plans.
+type RoomTypeAvailability struct {
+	RoomType
+	Available int    `json:"available"`
+	Quote     *Quote `json:"quote,omitempty"`
+}
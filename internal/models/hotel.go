@@ -2,8 +2,8 @@ package models
 
 type Hotel struct {
 	Id        int    `json:"id"`
-	Country   string `json:"country"`
-	City      string `json:"city"`
-	HotelName string `json:"hotel_name"`
-	Stars     int    `json:"stars"`
+	Country   string `json:"country" binding:"required,iso3166_1_alpha2"`
+	City      string `json:"city" binding:"required,max=100"`
+	HotelName string `json:"hotel_name" binding:"required,max=200"`
+	Stars     int    `json:"stars" binding:"required,min=1,max=5"`
 }
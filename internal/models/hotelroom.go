@@ -1,11 +1,11 @@
 package models
 
+// HotelRoom is a physical room of a hotel. RoomTypeId names the type it is
+// sold as, which must belong to the same hotel.
 type HotelRoom struct {
-	Id       int  `json:"id"`
-	HotelId  int  `json:"hotels_id"`
-	Rooms    int  `json:"rooms"`
-	Meals    bool `json:"meals"`
-	Bar      bool `json:"bar"`
-	Services bool `json:"services"`
-	Busy     bool `json:"busy"`
+	Id         int `json:"id"`
+	HotelId    int `json:"hotel_id" binding:"omitempty,gt=0"`
+	RoomTypeId int `json:"room_type_id" binding:"required,gt=0"`
+	RoomNumber int `json:"room_number" binding:"required,gt=0"`
+	Floor      int `json:"floor"`
 }
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Amount is a sum of money in minor units, e.g. cents, so prices add up
+// without floating point error. It is serialized as a decimal string with
+// two places, "120.50"; rate plans name the currency, which must be one of
+// those TwoDecimalCurrency accepts.
+type Amount int64
+
+// MaxPrice bounds the prices of rate plans, 10,000,000.00 in the plan's
+// currency, so quotes stay far from the range of an Amount.
+const MaxPrice Amount = 1_000_000_000
+
+// maxUnits is the largest whole part ParseAmount accepts with any fraction.
+const maxUnits = (math.MaxInt64 - 99) / 100
+
+// otherMinorUnits lists the ISO 4217 currencies whose minor unit is not a
+// hundredth of the major one, such as JPY and KWD, or that have none.
+var otherMinorUnits = map[string]bool{
+	// No decimals.
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "ISK": true, "JPY": true,
+	"KMF": true, "KRW": true, "PYG": true, "RWF": true, "UGX": true, "UYI": true,
+	"VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+	// Three or four decimals.
+	"BHD": true, "IQD": true, "JOD": true, "KWD": true, "LYD": true, "OMR": true,
+	"TND": true, "CLF": true, "UYW": true,
+	// Precious metals and other units without a minor unit.
+	"XAG": true, "XAU": true, "XBA": true, "XBB": true, "XBC": true, "XBD": true,
+	"XDR": true, "XPD": true, "XPT": true, "XSU": true, "XTS": true, "XUA": true,
+	"XXX": true,
+}
+
+// TwoDecimalCurrency reports whether amounts in the ISO 4217 currency code
+// have two decimal places, the only precision Amount represents.
+func TwoDecimalCurrency(code string) bool {
+	return !otherMinorUnits[code]
+}
+
+// ParseAmount parses a decimal amount with at most two places.
+func ParseAmount(s string) (Amount, error) {
+	invalid := fmt.Errorf("invalid amount %q, expected a decimal with at most two places", s)
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if hasFrac && (len(frac) == 0 || len(frac) > 2 || strings.Trim(frac, "0123456789") != "") {
+		return 0, invalid
+	}
+	if strings.HasPrefix(whole, "+") {
+		return 0, invalid
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > maxUnits || units < -maxUnits {
+		return 0, invalid
+	}
+
+	var cents int64
+	if hasFrac {
+		cents, _ = strconv.ParseInt(frac+strings.Repeat("0", 2-len(frac)), 10, 64)
+	}
+	if strings.HasPrefix(whole, "-") {
+		cents = -cents
+	}
+
+	return Amount(units*100 + cents), nil
+}
+
+func (a Amount) String() string {
+	sign := ""
+	if a < 0 {
+		sign, a = "-", -a
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, a/100, a%100)
+}
+
+// Add returns a+b; ok is false when the sum overflows an Amount.
+func (a Amount) Add(b Amount) (sum Amount, ok bool) {
+	sum = a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// Times returns a*n; ok is false when the product overflows an Amount.
+func (a Amount) Times(n int) (product Amount, ok bool) {
+	if a == 0 || n == 0 {
+		return 0, true
+	}
+	product = a * Amount(n)
+	if product/Amount(n) != a || (n == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
+
+// Percent returns p percent of a, rounded half away from zero to a whole
+// minor unit; ok is false when the computation overflows an Amount.
+func (a Amount) Percent(p int) (_ Amount, ok bool) {
+	x, ok := a.Times(p)
+	if !ok {
+		return 0, false
+	}
+	if x < 0 {
+		x, ok = x.Add(-50)
+	} else {
+		x, ok = x.Add(50)
+	}
+	if !ok {
+		return 0, false
+	}
+	return x / 100, true
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON accepts the amount as a string or as a JSON number. Numbers
+// are parsed from their text, never through a float.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+
+	*a = parsed
+	return nil
+}
@@ -0,0 +1,17 @@
+package models
+
+// HotelOccupancy counts the rooms of a hotel and how many of them are taken
+// by an active reservation on a given day.
+type HotelOccupancy struct {
+	HotelId  int
+	Rooms    int
+	Occupied int
+}
+
+// Ratio is the share of occupied rooms, 0 for a hotel without rooms.
+func (o HotelOccupancy) Ratio() float64 {
+	if o.Rooms == 0 {
+		return 0
+	}
+	return float64(o.Occupied) / float64(o.Rooms)
+}
@@ -0,0 +1,52 @@
+package models
+
+// RatePlan prices the nights of a room type in one currency. A night costs
+// BasePrice, or WeekendPrice on Friday and Saturday nights when that is set,
+// unless a season covering the night sets its own prices. Every guest above
+// the type's base occupancy adds ExtraGuestPrice per night, and the biggest
+// StayDiscount the stay is long enough for is taken off the total.
+type RatePlan struct {
+	Id              int            `json:"id"`
+	RoomTypeId      int            `json:"room_type_id"`
+	Name            string         `json:"name" binding:"required,max=100"`
+	Currency        string         `json:"currency" binding:"required,iso4217"`
+	BasePrice       Amount         `json:"base_price" binding:"gt=0"`
+	WeekendPrice    Amount         `json:"weekend_price" binding:"gte=0"`
+	ExtraGuestPrice Amount         `json:"extra_guest_price" binding:"gte=0"`
+	Seasons         []Season       `json:"seasons" binding:"dive"`
+	StayDiscounts   []StayDiscount `json:"stay_discounts" binding:"dive"`
+}
+
+// Season overrides the nightly prices of a rate plan for the nights from
+// From to To, both included. A zero WeekendPrice charges Price every night.
+type Season struct {
+	From         Date   `json:"from"`
+	To           Date   `json:"to"`
+	Price        Amount `json:"price" binding:"gt=0"`
+	WeekendPrice Amount `json:"weekend_price" binding:"gte=0"`
+}
+
+// StayDiscount takes Percent off stays of at least MinNights nights.
+type StayDiscount struct {
+	MinNights int `json:"min_nights" binding:"gte=2"`
+	Percent   int `json:"percent" binding:"min=1,max=100"`
+}
+
+// Quote is the itemized price of a stay under one rate plan.
+type Quote struct {
+	RatePlanId int           `json:"rate_plan_id"`
+	Currency   string        `json:"currency"`
+	Nights     []NightlyRate `json:"nights"`
+	Subtotal   Amount        `json:"subtotal"`
+	Discount   Amount        `json:"discount"`
+	Total      Amount        `json:"total"`
+}
+
+// NightlyRate is the price of one night of a stay: the room rate plus the
+// supplement for extra guests.
+type NightlyRate struct {
+	Date        Date   `json:"date"`
+	Rate        Amount `json:"rate"`
+	ExtraGuests Amount `json:"extra_guests"`
+	Amount      Amount `json:"amount"`
+}
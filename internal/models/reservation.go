@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+type ReservationStatus string
+
+const (
+	ReservationHeld       ReservationStatus = "held"
+	ReservationExpired    ReservationStatus = "expired"
+	ReservationPending    ReservationStatus = "pending"
+	ReservationConfirmed  ReservationStatus = "confirmed"
+	ReservationCancelled  ReservationStatus = "cancelled"
+	ReservationCheckedIn  ReservationStatus = "checked_in"
+	ReservationCheckedOut ReservationStatus = "checked_out"
+)
+
+// Reservation books a room type for a stay. HotelRoomId is 0 until a physical
+// room is assigned at check-in. RatePlanId and Total record the price the
+// stay was booked at; both are 0 when the room type had no rate plans. A
+// held reservation blocks its room type until ExpiresAt; confirming it
+// before then makes it a booking and clears ExpiresAt.
+type Reservation struct {
+	Id          int               `json:"id"`
+	RoomTypeId  int               `json:"room_type_id" binding:"required,gt=0"`
+	HotelRoomId int               `json:"hotel_room_id,omitempty"`
+	VisitorId   int               `json:"visitor_id" binding:"required,gt=0"`
+	CheckIn     Date              `json:"check_in"`
+	CheckOut    Date              `json:"check_out"`
+	Guests      int               `json:"guests" binding:"required,gt=0"`
+	Status      ReservationStatus `json:"status"`
+	CreatedAt   time.Time         `json:"created_at"`
+	RatePlanId  int               `json:"rate_plan_id,omitempty" binding:"omitempty,gt=0"`
+	Currency    string            `json:"currency,omitempty"`
+	Total       Amount            `json:"total,omitempty"`
+	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
+}
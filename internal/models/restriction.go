@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// StayRestriction limits the stays of a room type around one date. MinStay,
+// MaxStay and ClosedToArrival apply to stays arriving on Date,
+// ClosedToDeparture to stays leaving on it and StopSell to every stay that
+// spends the night of Date. A zero MinStay or MaxStay does not restrict.
+type StayRestriction struct {
+	RoomTypeId        int  `json:"room_type_id"`
+	Date              Date `json:"date"`
+	MinStay           int  `json:"min_stay"`
+	MaxStay           int  `json:"max_stay"`
+	ClosedToArrival   bool `json:"closed_to_arrival"`
+	ClosedToDeparture bool `json:"closed_to_departure"`
+	StopSell          bool `json:"stop_sell"`
+}
+
+// Restricts reports whether r limits any stay at all.
+func (r StayRestriction) Restricts() bool {
+	return r.MinStay != 0 || r.MaxStay != 0 || r.ClosedToArrival || r.ClosedToDeparture || r.StopSell
+}
+
+// RestrictionUpdate changes the restrictions of every date from From to To,
+// both included, that falls on one of Weekdays, or of every date in the range
+// when Weekdays is empty. Nil fields keep the current value; zero and false
+// lift the restriction. "min 3 nights every weekend in July" is
+//
+//	{"from": "2030-07-01", "to": "2030-07-31", "weekdays": ["fri", "sat"], "min_stay": 3}
+type RestrictionUpdate struct {
+	From              Date     `json:"from"`
+	To                Date     `json:"to"`
+	Weekdays          []string `json:"weekdays" binding:"dive,oneof=mon tue wed thu fri sat sun"`
+	MinStay           *int     `json:"min_stay" binding:"omitempty,gte=0"`
+	MaxStay           *int     `json:"max_stay" binding:"omitempty,gte=0"`
+	ClosedToArrival   *bool    `json:"closed_to_arrival"`
+	ClosedToDeparture *bool    `json:"closed_to_departure"`
+	StopSell          *bool    `json:"stop_sell"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+	"fri": time.Friday, "sat": time.Saturday, "sun": time.Sunday,
+}
+
+// Dates returns the dates the update applies to in ascending order.
+func (u RestrictionUpdate) Dates() []Date {
+	var dates []Date
+	for d := u.From; !d.After(u.To.Time); d = NewDate(d.AddDate(0, 0, 1)) {
+		if u.appliesOn(d.Weekday()) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+func (u RestrictionUpdate) appliesOn(day time.Weekday) bool {
+	if len(u.Weekdays) == 0 {
+		return true
+	}
+	for _, code := range u.Weekdays {
+		if weekdays[code] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns r with the fields u sets replaced.
+func (u RestrictionUpdate) Apply(r StayRestriction) StayRestriction {
+	if u.MinStay != nil {
+		r.MinStay = *u.MinStay
+	}
+	if u.MaxStay != nil {
+		r.MaxStay = *u.MaxStay
+	}
+	if u.ClosedToArrival != nil {
+		r.ClosedToArrival = *u.ClosedToArrival
+	}
+	if u.ClosedToDeparture != nil {
+		r.ClosedToDeparture = *u.ClosedToDeparture
+	}
+	if u.StopSell != nil {
+		r.StopSell = *u.StopSell
+	}
+	return r
+}
@@ -0,0 +1,21 @@
+package models
+
+// RoomType is what guests book: a product of a hotel with its capacity and
+// beds. Physical rooms (HotelRoom) belong to a type and are assigned to a
+// reservation at check-in. Its amenities are listed separately.
+type RoomType struct {
+	Id               int    `json:"id"`
+	HotelId          int    `json:"hotel_id"`
+	Name             string `json:"name" binding:"required,max=100"`
+	Description      string `json:"description" binding:"max=2000"`
+	MaxAdults        int    `json:"max_adults" binding:"required,gt=0"`
+	MaxChildren      int    `json:"max_children" binding:"gte=0"`
+	BedConfiguration string `json:"bed_configuration" binding:"max=200"`
+	BaseOccupancy    int    `json:"base_occupancy" binding:"required,gt=0"`
+	SizeSqm          int    `json:"size_sqm" binding:"gte=0"`
+}
+
+// MaxGuests is the number of guests the type can host.
+func (rt RoomType) MaxGuests() int {
+	return rt.MaxAdults + rt.MaxChildren
+}
@@ -1,10 +1,29 @@
 package models
 
+import (
+	"bookings/internal/pii"
+	"log/slog"
+	"strconv"
+)
+
 type Visitor struct {
 	Id        int    `json:"visitor_id"`
-	HotelId   int    `json:"hotel_id"`
-	HotelRoom int    `json:"hotel_room_id"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Age       int    `json:"age"`
+	HotelId   int    `json:"hotel_id" binding:"required,gt=0"`
+	HotelRoom int    `json:"hotel_room_id" binding:"required,gt=0"`
+	FirstName string `json:"first_name" binding:"required,max=100"`
+	LastName  string `json:"last_name" binding:"required,max=100"`
+	Age       int    `json:"age" binding:"required,min=18,max=100"`
+}
+
+// LogValue masks the visitor's name and age unless personal data logging is
+// enabled for debugging.
+func (v Visitor) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("visitor_id", v.Id),
+		slog.Int("hotel_id", v.HotelId),
+		slog.Int("hotel_room_id", v.HotelRoom),
+		slog.String("first_name", pii.Mask(v.FirstName)),
+		slog.String("last_name", pii.Mask(v.LastName)),
+		slog.String("age", pii.String(strconv.Itoa(v.Age))),
+	)
 }
@@ -0,0 +1,109 @@
+// Package pii masks personal data before it is logged. Masking is on by
+// default; SetReveal turns it off for local debugging.
+package pii
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+const Redacted = "***"
+
+var reveal atomic.Bool
+
+// SetReveal controls whether personal data is logged in clear text. It is
+// meant for local debugging only and is off unless LOG_PII is set.
+func SetReveal(on bool) {
+	reveal.Store(on)
+}
+
+// Revealed reports whether personal data is logged in clear text.
+func Revealed() bool {
+	return reveal.Load()
+}
+
+// Keys are the attribute keys that hold personal data wherever they appear,
+// including inside groups.
+var Keys = map[string]bool{
+	"first_name":        true,
+	"last_name":         true,
+	"full_name":         true,
+	"age":               true,
+	"email":             true,
+	"phone":             true,
+	"payment_reference": true,
+}
+
+// Mask keeps the first character of s so records stay distinguishable while
+// debugging, e.g. "Johnson" becomes "J***".
+func Mask(s string) string {
+	if reveal.Load() {
+		return s
+	}
+	if s == "" {
+		return ""
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return string(r) + Redacted
+}
+
+// MaskEmail keeps the first character of the local part and the domain.
+func MaskEmail(email string) string {
+	if reveal.Load() {
+		return email
+	}
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return Mask(email)
+	}
+	return Mask(local) + "@" + domain
+}
+
+// String returns s unless masking is on. Use it for values such as ages or
+// phone numbers where even a prefix is too much.
+func String(s string) string {
+	if reveal.Load() {
+		return s
+	}
+	return Redacted
+}
+
+// ReplaceAttr is a slog.HandlerOptions.ReplaceAttr that masks the values of
+// Keys. It is the safety net for attributes logged without a LogValuer.
+func ReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if reveal.Load() || !Keys[a.Key] {
+		return a
+	}
+
+	switch a.Key {
+	case "email":
+		return slog.String(a.Key, MaskEmail(a.Value.String()))
+	case "first_name", "last_name", "full_name":
+		return slog.String(a.Key, Mask(a.Value.String()))
+	default:
+		return slog.String(a.Key, Redacted)
+	}
+}
+
+// Postgres repeats offending values in error messages and details, e.g.
+// `Key (email)=(a@b.c) already exists` or `invalid input syntax for type
+// integer: "John"`.
+var (
+	keyValue    = regexp.MustCompile(`\)=\((.*?)\)( |$|\.)`)
+	failingRow  = regexp.MustCompile(`(?s)Failing row contains \(.*\)`)
+	quotedValue = regexp.MustCompile(`: "(?s:.*)"`)
+)
+
+// ScrubText removes the values postgres echoes in error text.
+func ScrubText(s string) string {
+	if reveal.Load() {
+		return s
+	}
+	s = keyValue.ReplaceAllString(s, ")=("+Redacted+")$2")
+	s = failingRow.ReplaceAllString(s, "Failing row contains ("+Redacted+")")
+	s = quotedValue.ReplaceAllString(s, `: "`+Redacted+`"`)
+	return s
+}
@@ -0,0 +1,88 @@
+package pii_test
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/pii"
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestScrubText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{
+			`ERROR: invalid input syntax for type integer: "John Smith" (SQLSTATE 22P02)`,
+			`ERROR: invalid input syntax for type integer: "***" (SQLSTATE 22P02)`,
+		},
+		{
+			`Key (email)=(john@example.com) already exists.`,
+			`Key (email)=(***) already exists.`,
+		},
+		{
+			`Key (hotel_id, room_number)=(1, 101) is not present in table "hotels".`,
+			`Key (hotel_id, room_number)=(***) is not present in table "hotels".`,
+		},
+		{
+			`Failing row contains (7, 1, 2, John, Smith, 17).`,
+			`Failing row contains (***).`,
+		},
+		{
+			`ERROR: duplicate key value violates unique constraint "hotels_hotel_name_key" (SQLSTATE 23505)`,
+			`ERROR: duplicate key value violates unique constraint "hotels_hotel_name_key" (SQLSTATE 23505)`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := pii.ScrubText(tt.in); got != tt.want {
+			t.Errorf("ScrubText(%q)\n got %q\nwant %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactedLogs(t *testing.T) {
+	visitor := models.Visitor{Id: 3, HotelId: 1, HotelRoom: 2, FirstName: "John", LastName: "Smith", Age: 42}
+
+	logLine := func() string {
+		var buf bytes.Buffer
+		// The timestamp is dropped so its digits cannot look like a leak.
+		log := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return pii.ReplaceAttr(groups, a)
+			},
+		}))
+		log.Info("visitor created",
+			slog.Any("visitor", visitor),
+			slog.String("email", "john@example.com"),
+			slog.Group("guest", slog.String("last_name", "Smith"), slog.Int("age", 42)),
+		)
+		return buf.String()
+	}
+
+	masked := logLine()
+	for _, leak := range []string{"John", "Smith", "42", "john@"} {
+		if strings.Contains(masked, leak) {
+			t.Errorf("log leaks %q: %s", leak, masked)
+		}
+	}
+	for _, want := range []string{`"visitor_id":3`, `"first_name":"J***"`, `"email":"j***@example.com"`, `"age":"***"`} {
+		if !strings.Contains(masked, want) {
+			t.Errorf("log lacks %s: %s", want, masked)
+		}
+	}
+
+	pii.SetReveal(true)
+	defer pii.SetReveal(false)
+
+	revealed := logLine()
+	for _, want := range []string{`"first_name":"John"`, `"last_name":"Smith"`, `"email":"john@example.com"`} {
+		if !strings.Contains(revealed, want) {
+			t.Errorf("revealed log lacks %s: %s", want, revealed)
+		}
+	}
+}
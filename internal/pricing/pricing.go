@@ -0,0 +1,104 @@
+// Package pricing computes what a stay costs under a rate plan. Storage
+// quotes availability results and new reservations through it, so a guest
+// is charged the price they were shown.
+package pricing
+
+import (
+	"bookings/internal/models"
+	"errors"
+	"time"
+)
+
+// ErrOverflow is returned for a stay whose price does not fit an Amount.
+var ErrOverflow = errors.New("stay price out of range")
+
+// Quote prices the nights from checkIn to checkOut for guests under plan,
+// which must belong to roomType. It does not check availability, nor the
+// length of the stay, which storage bounds before pricing. Every sum is
+// checked, so a price too big to represent fails with ErrOverflow instead of
+// wrapping.
+func Quote(plan models.RatePlan, roomType models.RoomType, checkIn, checkOut models.Date, guests int) (models.Quote, error) {
+	quote := models.Quote{RatePlanId: plan.Id, Currency: plan.Currency, Nights: []models.NightlyRate{}}
+
+	extraGuests := max(guests-roomType.BaseOccupancy, 0)
+
+	extraGuestsPrice, ok := plan.ExtraGuestPrice.Times(extraGuests)
+	if !ok {
+		return models.Quote{}, ErrOverflow
+	}
+
+	for night := checkIn; night.Before(checkOut.Time); night = models.NewDate(night.AddDate(0, 0, 1)) {
+		rate := models.NightlyRate{
+			Date:        night,
+			Rate:        nightlyRate(plan, night),
+			ExtraGuests: extraGuestsPrice,
+		}
+
+		if rate.Amount, ok = rate.Rate.Add(rate.ExtraGuests); !ok {
+			return models.Quote{}, ErrOverflow
+		}
+		if quote.Subtotal, ok = quote.Subtotal.Add(rate.Amount); !ok {
+			return models.Quote{}, ErrOverflow
+		}
+		quote.Nights = append(quote.Nights, rate)
+	}
+
+	if quote.Discount, ok = quote.Subtotal.Percent(stayDiscount(plan, len(quote.Nights))); !ok {
+		return models.Quote{}, ErrOverflow
+	}
+	// The discount is at most the subtotal, so the total cannot overflow.
+	quote.Total = quote.Subtotal - quote.Discount
+
+	return quote, nil
+}
+
+// Cheapest quotes the stay under every plan and returns the lowest total;
+// ties go to the plan listed first. ok is false when plans is empty, and
+// err is ErrOverflow when a plan prices the stay out of range.
+func Cheapest(plans []models.RatePlan, roomType models.RoomType, checkIn, checkOut models.Date, guests int) (cheapest models.Quote, ok bool, err error) {
+	for _, plan := range plans {
+		quote, err := Quote(plan, roomType, checkIn, checkOut, guests)
+		if err != nil {
+			return models.Quote{}, false, err
+		}
+		if !ok || quote.Total < cheapest.Total {
+			cheapest, ok = quote, true
+		}
+	}
+	return cheapest, ok, nil
+}
+
+// nightlyRate is the room rate of the night starting on day: the season's
+// prices if one covers it, the plan's otherwise.
+func nightlyRate(plan models.RatePlan, day models.Date) models.Amount {
+	price, weekendPrice := plan.BasePrice, plan.WeekendPrice
+	for _, season := range plan.Seasons {
+		if !day.Before(season.From.Time) && !day.After(season.To.Time) {
+			price, weekendPrice = season.Price, season.WeekendPrice
+			break
+		}
+	}
+
+	if weekendPrice != 0 && isWeekendNight(day) {
+		return weekendPrice
+	}
+	return price
+}
+
+// isWeekendNight reports whether the night starting on day is a Friday or
+// Saturday night.
+func isWeekendNight(day models.Date) bool {
+	return day.Weekday() == time.Friday || day.Weekday() == time.Saturday
+}
+
+// stayDiscount returns the percentage of the biggest discount a stay of
+// nights nights qualifies for, or 0.
+func stayDiscount(plan models.RatePlan, nights int) int {
+	percent := 0
+	for _, d := range plan.StayDiscounts {
+		if nights >= d.MinNights && d.Percent > percent {
+			percent = d.Percent
+		}
+	}
+	return percent
+}
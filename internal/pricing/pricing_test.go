@@ -0,0 +1,167 @@
+package pricing_test
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/pricing"
+	"errors"
+	"math"
+	"testing"
+)
+
+func date(t *testing.T, s string) models.Date {
+	t.Helper()
+
+	d, err := models.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func amount(t *testing.T, s string) models.Amount {
+	t.Helper()
+
+	a, err := models.ParseAmount(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestQuote(t *testing.T) {
+	roomType := models.RoomType{Id: 1, MaxAdults: 4, BaseOccupancy: 2}
+	plan := models.RatePlan{
+		Id:              7,
+		RoomTypeId:      roomType.Id,
+		Currency:        "EUR",
+		BasePrice:       amount(t, "100"),
+		WeekendPrice:    amount(t, "130"),
+		ExtraGuestPrice: amount(t, "15.50"),
+		Seasons: []models.Season{
+			{From: date(t, "2030-07-07"), To: date(t, "2030-07-08"), Price: amount(t, "200")},
+		},
+		StayDiscounts: []models.StayDiscount{{MinNights: 3, Percent: 10}, {MinNights: 5, Percent: 15}},
+	}
+
+	// 2030-07-05 is a Friday.
+	tests := []struct {
+		name     string
+		checkIn  string
+		checkOut string
+		guests   int
+		nights   []string
+		subtotal string
+		discount string
+		total    string
+	}{
+		{"weekday", "2030-07-01", "2030-07-02", 2, []string{"100.00"}, "100.00", "0.00", "100.00"},
+		{"weekend nights", "2030-07-04", "2030-07-07", 2, []string{"100.00", "130.00", "130.00"}, "360.00", "36.00", "324.00"},
+		{"season and extra guest", "2030-07-06", "2030-07-09", 3, []string{"145.50", "215.50", "215.50"}, "576.50", "57.65", "518.85"},
+		{"biggest discount", "2030-07-01", "2030-07-06", 1, []string{"100.00", "100.00", "100.00", "100.00", "130.00"}, "530.00", "79.50", "450.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := pricing.Quote(plan, roomType, date(t, tt.checkIn), date(t, tt.checkOut), tt.guests)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if quote.RatePlanId != plan.Id || quote.Currency != "EUR" || len(quote.Nights) != len(tt.nights) {
+				t.Fatalf("quote = %+v", quote)
+			}
+			for i, night := range quote.Nights {
+				if night.Amount.String() != tt.nights[i] || night.Amount != night.Rate+night.ExtraGuests {
+					t.Fatalf("night %d = %+v, want amount %s", i, night, tt.nights[i])
+				}
+			}
+			if quote.Subtotal.String() != tt.subtotal || quote.Discount.String() != tt.discount || quote.Total.String() != tt.total {
+				t.Fatalf("quote subtotal %s, discount %s, total %s, want %s, %s, %s",
+					quote.Subtotal, quote.Discount, quote.Total, tt.subtotal, tt.discount, tt.total)
+			}
+		})
+	}
+}
+
+func TestQuoteRoundsDiscountToCents(t *testing.T) {
+	plan := models.RatePlan{BasePrice: amount(t, "0.45"), StayDiscounts: []models.StayDiscount{{MinNights: 3, Percent: 10}}}
+
+	quote, err := pricing.Quote(plan, models.RoomType{BaseOccupancy: 1}, date(t, "2030-07-01"), date(t, "2030-07-04"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.Discount.String() != "0.14" || quote.Total.String() != "1.21" {
+		t.Fatalf("discount %s, total %s, want 0.14 and 1.21", quote.Discount, quote.Total)
+	}
+}
+
+func TestCheapest(t *testing.T) {
+	roomType := models.RoomType{BaseOccupancy: 1}
+	plans := []models.RatePlan{
+		{Id: 1, BasePrice: amount(t, "90")},
+		{Id: 2, BasePrice: amount(t, "100"), StayDiscounts: []models.StayDiscount{{MinNights: 2, Percent: 20}}},
+	}
+
+	quote, ok, err := pricing.Cheapest(plans, roomType, date(t, "2030-07-01"), date(t, "2030-07-02"), 1)
+	if err != nil || !ok || quote.RatePlanId != 1 {
+		t.Fatalf("one night: cheapest = %+v, %v, want plan 1", quote, ok)
+	}
+
+	quote, ok, err = pricing.Cheapest(plans, roomType, date(t, "2030-07-01"), date(t, "2030-07-03"), 1)
+	if err != nil || !ok || quote.RatePlanId != 2 || quote.Total.String() != "160.00" {
+		t.Fatalf("two nights: cheapest = %+v, %v, want plan 2 at 160.00", quote, ok)
+	}
+
+	if _, ok, _ := pricing.Cheapest(nil, roomType, date(t, "2030-07-01"), date(t, "2030-07-02"), 1); ok {
+		t.Fatal("Cheapest without plans reported a quote")
+	}
+}
+
+func TestQuoteOverflow(t *testing.T) {
+	huge := models.Amount(math.MaxInt64 / 200)
+
+	tests := []struct {
+		name   string
+		plan   models.RatePlan
+		guests int
+	}{
+		{"nightly sum", models.RatePlan{BasePrice: math.MaxInt64 / 2, ExtraGuestPrice: math.MaxInt64 / 4}, 3},
+		{"extra guests", models.RatePlan{BasePrice: 100, ExtraGuestPrice: huge}, 1000},
+		{"subtotal", models.RatePlan{BasePrice: huge}, 1},
+		{"discount", models.RatePlan{BasePrice: models.Amount(math.MaxInt64 / 400), StayDiscounts: []models.StayDiscount{{MinNights: 2, Percent: 50}}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := pricing.Quote(tt.plan, models.RoomType{BaseOccupancy: 1}, date(t, "2030-01-01"), date(t, "2031-01-01"), tt.guests)
+			if !errors.Is(err, pricing.ErrOverflow) {
+				t.Fatalf("Quote = %+v, %v, want ErrOverflow", quote.Total, err)
+			}
+		})
+	}
+
+	plans := []models.RatePlan{{Id: 1, BasePrice: 100}, {Id: 2, BasePrice: huge}}
+	if _, _, err := pricing.Cheapest(plans, models.RoomType{BaseOccupancy: 1}, date(t, "2030-01-01"), date(t, "2031-01-01"), 1); !errors.Is(err, pricing.ErrOverflow) {
+		t.Fatalf("Cheapest err = %v, want ErrOverflow", err)
+	}
+
+	// The largest prices fit every stay storage accepts.
+	max := models.RatePlan{BasePrice: models.MaxPrice, ExtraGuestPrice: models.MaxPrice, StayDiscounts: []models.StayDiscount{{MinNights: 2, Percent: 100}}}
+	if _, err := pricing.Quote(max, models.RoomType{BaseOccupancy: 1}, date(t, "2030-01-01"), date(t, "2031-01-01"), 10); err != nil {
+		t.Fatalf("Quote at MaxPrice: %v", err)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	for s, want := range map[string]models.Amount{"12": 1200, "12.5": 1250, "0.05": 5, "-1.25": -125} {
+		if got, err := models.ParseAmount(s); err != nil || got != want {
+			t.Errorf("ParseAmount(%q) = %d, %v, want %d", s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"", "1.", "1.234", "+1", "1e3", "1.5x", "abc", "184467440737095517", "92233720368547758.07", "-92233720368547758"} {
+		if got, err := models.ParseAmount(s); err == nil {
+			t.Errorf("ParseAmount(%q) = %d, want an error", s, got)
+		}
+	}
+}
@@ -1,24 +1,108 @@
 package router
 
 import (
-	"bookings/internal/config"
+	"bookings/internal/handlers/amenityHandlers"
+	"bookings/internal/handlers/availabilityHandlers"
 	handlers "bookings/internal/handlers/hotelHandlers"
+	"bookings/internal/handlers/hotelRoomHandlers"
+	"bookings/internal/handlers/ratePlanHandlers"
+	"bookings/internal/handlers/reservationHandlers"
+	"bookings/internal/handlers/restrictionHandlers"
+	"bookings/internal/handlers/roomTypeHandlers"
+	"bookings/internal/handlers/visitorHandlers"
+	"bookings/internal/health"
+	"bookings/internal/middleware"
 	"bookings/internal/storage"
 	"log/slog"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
 )
 
-func SetupRouter() *gin.Engine {
-	r := gin.Default()
-	cfg := config.MustLoad()
-	postgres, _ := storage.NewPostgresDb(cfg)
+// SetupRouter registers every route on a new engine. All handlers share log
+// and repo, which the caller owns and closes; checker backs /readyz.
+func SetupRouter(log *slog.Logger, repo storage.Repository, checker *health.Checker) *gin.Engine {
+	r := gin.New()
+	r.Use(
+		middleware.RequestID(),
+		middleware.Tracing(otel.GetTracerProvider()),
+		middleware.AccessLog(log),
+		middleware.Recover(log),
+		middleware.Metrics(),
+		middleware.Problems(log),
+	)
+
+	r.GET("/healthz", health.Healthz())
+	r.GET("/readyz", checker.Readyz())
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	groupHotels := r.Group("/hotel")
-	groupHotels.POST("/", handlers.PostHotelHandler(slog.Default(), postgres))
-	groupHotels.GET("/", handlers.GetAllHotelHandler(slog.Default(), postgres))
-	groupHotels.GET("/:id", handlers.GetHotelHandler(*slog.Default(), postgres))
-	// groupHotels.DELETE("/:id", )
-	// groupHotels.PUT("/:id", )
+	groupHotels.POST("/", handlers.PostHotelHandler(log, repo))
+	groupHotels.GET("/", handlers.GetAllHotelHandler(log, repo))
+	groupHotels.GET("/:id", handlers.GetHotelHandler(log, repo))
+	groupHotels.PUT("/:id", handlers.PutHotelHandler(log, repo))
+	groupHotels.DELETE("/:id", handlers.DeleteHotelHandler(log, repo))
+	groupHotels.GET("/:id/room-types", roomTypeHandlers.GetRoomTypesByHotelHandler(log, repo))
+	groupHotels.POST("/:id/room-types", roomTypeHandlers.PostRoomTypeHandler(log, repo))
+	groupHotels.GET("/:id/amenities", amenityHandlers.GetHotelAmenitiesHandler(log, repo))
+	groupHotels.PUT("/:id/amenities", amenityHandlers.PutHotelAmenitiesHandler(log, repo))
+	groupHotels.GET("/:id/rooms", hotelRoomHandlers.GetHotelRoomsByHotelHandler(log, repo))
+	groupHotels.POST("/:id/rooms", hotelRoomHandlers.PostHotelRoomHandler(log, repo))
+	groupHotels.GET("/:id/visitors", visitorHandlers.GetVisitorsByHotelHandler(log, repo))
+	groupHotels.GET("/:id/reservations", reservationHandlers.GetReservationsByHotelHandler(log, repo))
+
+	groupRoomTypes := r.Group("/room-types")
+	groupRoomTypes.GET("/", roomTypeHandlers.GetAllRoomTypesHandler(log, repo))
+	groupRoomTypes.GET("/:roomTypeId", roomTypeHandlers.GetRoomTypeHandler(log, repo))
+	groupRoomTypes.PUT("/:roomTypeId", roomTypeHandlers.PutRoomTypeHandler(log, repo))
+	groupRoomTypes.DELETE("/:roomTypeId", roomTypeHandlers.DeleteRoomTypeHandler(log, repo))
+	groupRoomTypes.GET("/:roomTypeId/rooms", hotelRoomHandlers.GetHotelRoomsByRoomTypeHandler(log, repo))
+	groupRoomTypes.GET("/:roomTypeId/amenities", amenityHandlers.GetRoomTypeAmenitiesHandler(log, repo))
+	groupRoomTypes.PUT("/:roomTypeId/amenities", amenityHandlers.PutRoomTypeAmenitiesHandler(log, repo))
+	groupRoomTypes.GET("/:roomTypeId/rate-plans", ratePlanHandlers.GetRatePlansByRoomTypeHandler(log, repo))
+	groupRoomTypes.POST("/:roomTypeId/rate-plans", ratePlanHandlers.PostRatePlanHandler(log, repo))
+	groupRoomTypes.GET("/:roomTypeId/quote", ratePlanHandlers.GetQuoteHandler(log, repo))
+	groupRoomTypes.GET("/:roomTypeId/restrictions", restrictionHandlers.GetRestrictionsHandler(log, repo))
+	groupRoomTypes.PATCH("/:roomTypeId/restrictions", restrictionHandlers.PatchRestrictionsHandler(log, repo))
+
+	groupRatePlans := r.Group("/rate-plans")
+	groupRatePlans.GET("/:ratePlanId", ratePlanHandlers.GetRatePlanHandler(log, repo))
+	groupRatePlans.PUT("/:ratePlanId", ratePlanHandlers.PutRatePlanHandler(log, repo))
+	groupRatePlans.DELETE("/:ratePlanId", ratePlanHandlers.DeleteRatePlanHandler(log, repo))
+
+	groupAmenities := r.Group("/amenities")
+	groupAmenities.POST("/", amenityHandlers.PostAmenityHandler(log, repo))
+	groupAmenities.GET("/", amenityHandlers.GetAllAmenitiesHandler(log, repo))
+	groupAmenities.GET("/:code", amenityHandlers.GetAmenityHandler(log, repo))
+	groupAmenities.PUT("/:code", amenityHandlers.PutAmenityHandler(log, repo))
+	groupAmenities.DELETE("/:code", amenityHandlers.DeleteAmenityHandler(log, repo))
+
+	groupRooms := r.Group("/rooms")
+	groupRooms.GET("/", hotelRoomHandlers.GetAllHotelRoomsHandler(log, repo))
+	groupRooms.GET("/:roomId", hotelRoomHandlers.GetHotelRoomHandler(log, repo))
+	groupRooms.PUT("/:roomId", hotelRoomHandlers.PutHotelRoomHandler(log, repo))
+	groupRooms.DELETE("/:roomId", hotelRoomHandlers.DeleteHotelRoomHandler(log, repo))
+	groupRooms.GET("/:roomId/visitors", visitorHandlers.GetVisitorsByHotelRoomHandler(log, repo))
+	groupRooms.GET("/:roomId/reservations", reservationHandlers.GetReservationsByHotelRoomHandler(log, repo))
+
+	groupVisitors := r.Group("/visitors")
+	groupVisitors.POST("/", visitorHandlers.PostVisitorHandler(log, repo))
+	groupVisitors.GET("/", visitorHandlers.GetAllVisitorsHandler(log, repo))
+	groupVisitors.GET("/:visitorId", visitorHandlers.GetVisitorHandler(log, repo))
+	groupVisitors.PUT("/:visitorId", visitorHandlers.PutVisitorHandler(log, repo))
+	groupVisitors.DELETE("/:visitorId", visitorHandlers.DeleteVisitorHandler(log, repo))
+
+	groupReservations := r.Group("/reservations")
+	groupReservations.POST("/", reservationHandlers.PostReservationHandler(log, repo))
+	groupReservations.GET("/", reservationHandlers.GetAllReservationsHandler(log, repo))
+	groupReservations.POST("/holds", reservationHandlers.PostHoldHandler(log, repo))
+	groupReservations.GET("/:reservationId", reservationHandlers.GetReservationHandler(log, repo))
+	groupReservations.POST("/:reservationId/confirm", reservationHandlers.ConfirmReservationHandler(log, repo))
+	groupReservations.POST("/:reservationId/cancel", reservationHandlers.CancelReservationHandler(log, repo))
+	groupReservations.POST("/:reservationId/check-in", reservationHandlers.CheckInReservationHandler(log, repo))
+
+	r.GET("/availability", availabilityHandlers.GetAvailabilityHandler(log, repo))
+
 	return r
 }
@@ -0,0 +1,292 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const amenityColumns = `a.code, a.category, a.labels`
+
+// AmenityFilter selects catalog entries for ListAmenities. An empty Category
+// does not filter.
+type AmenityFilter struct {
+	Category string `form:"category" binding:"max=50"`
+}
+
+// AmenityCodes normalises the amenity codes of a filter or request: each
+// value may hold several comma separated codes, as in ?amenities=wifi,pool.
+// Blank codes and repeats are dropped.
+func AmenityCodes(values []string) []string {
+	codes := []string{}
+	for _, v := range values {
+		for _, code := range strings.Split(v, ",") {
+			code = strings.TrimSpace(code)
+			if code != "" && !slices.Contains(codes, code) {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return codes
+}
+
+func (pos *Postgres) CreateAmenity(ctx context.Context, a models.Amenity) (_ models.Amenity, err error) {
+	const op = "storage.postgres.CreateAmenity"
+	defer observe(ctx, op, time.Now(), &err)
+
+	a.Labels = amenityLabels(a.Labels)
+
+	_, err = pos.pool.Exec(ctx, "create_amenity", a.Code, a.Category, a.Labels)
+	if err != nil {
+		return models.Amenity{}, fmt.Errorf("%s: exec failed: %w", op, amenityWriteErr(err))
+	}
+
+	return a, nil
+}
+
+func (pos *Postgres) ListAmenities(ctx context.Context, filter AmenityFilter) (_ []models.Amenity, err error) {
+	const op = "storage.postgres.ListAmenities"
+	defer observe(ctx, op, time.Now(), &err)
+
+	amenities, err := queryAmenities(ctx, pos.pool, "list_amenities", filter.Category)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return amenities, nil
+}
+
+func (pos *Postgres) GetAmenity(ctx context.Context, code string) (_ models.Amenity, err error) {
+	const op = "storage.postgres.GetAmenity"
+	defer observe(ctx, op, time.Now(), &err)
+
+	a, err := scanAmenity(pos.pool.QueryRow(ctx, "get_amenity", code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Amenity{}, fmt.Errorf("%s: %w", op, ErrAmenityNotFound)
+		}
+		return models.Amenity{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+
+	return a, nil
+}
+
+func (pos *Postgres) UpdateAmenity(ctx context.Context, code string, a models.Amenity) (_ models.Amenity, err error) {
+	const op = "storage.postgres.UpdateAmenity"
+	defer observe(ctx, op, time.Now(), &err)
+
+	// The code is the key hotels and room types link by; it never changes.
+	a.Code, a.Labels = code, amenityLabels(a.Labels)
+
+	tag, err := pos.pool.Exec(ctx, "update_amenity", a.Category, a.Labels, code)
+	if err != nil {
+		return models.Amenity{}, fmt.Errorf("%s: update failed: %w", op, amenityWriteErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.Amenity{}, fmt.Errorf("%s: %w", op, ErrAmenityNotFound)
+	}
+
+	return a, nil
+}
+
+func (pos *Postgres) DeleteAmenity(ctx context.Context, code string) (err error) {
+	const op = "storage.postgres.DeleteAmenity"
+	defer observe(ctx, op, time.Now(), &err)
+
+	tag, err := pos.pool.Exec(ctx, "delete_amenity", code)
+	if err != nil {
+		if pgErrCode(err) == pgForeignKeyViolation {
+			return fmt.Errorf("%s: %w", op, ErrAmenityInUse)
+		}
+		return fmt.Errorf("%s: delete failed: %w", op, pgError(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrAmenityNotFound)
+	}
+
+	return nil
+}
+
+func (pos *Postgres) ListHotelAmenities(ctx context.Context, hotelId int) (_ []models.Amenity, err error) {
+	const op = "storage.postgres.ListHotelAmenities"
+	defer observe(ctx, op, time.Now(), &err)
+
+	amenities, err := queryAmenities(ctx, pos.pool, "list_hotel_amenities", hotelId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// An empty list may also mean there is no such hotel.
+	if len(amenities) == 0 {
+		if _, err := pos.GetHotel(ctx, hotelId); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return amenities, nil
+}
+
+func (pos *Postgres) SetHotelAmenities(ctx context.Context, hotelId int, codes []string) (_ []models.Amenity, err error) {
+	const op = "storage.postgres.SetHotelAmenities"
+	defer observe(ctx, op, time.Now(), &err)
+
+	amenities, err := pos.setAmenities(ctx, amenityLinks{
+		lock:     "lock_hotel",
+		clear:    "clear_hotel_amenities",
+		add:      "add_hotel_amenities",
+		list:     "list_hotel_amenities",
+		notFound: ErrHotelNotFound,
+	}, hotelId, codes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return amenities, nil
+}
+
+func (pos *Postgres) ListRoomTypeAmenities(ctx context.Context, roomTypeId int) (_ []models.Amenity, err error) {
+	const op = "storage.postgres.ListRoomTypeAmenities"
+	defer observe(ctx, op, time.Now(), &err)
+
+	amenities, err := queryAmenities(ctx, pos.pool, "list_room_type_amenities", roomTypeId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// An empty list may also mean there is no such room type.
+	if len(amenities) == 0 {
+		if _, err := pos.GetRoomType(ctx, roomTypeId); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return amenities, nil
+}
+
+func (pos *Postgres) SetRoomTypeAmenities(ctx context.Context, roomTypeId int, codes []string) (_ []models.Amenity, err error) {
+	const op = "storage.postgres.SetRoomTypeAmenities"
+	defer observe(ctx, op, time.Now(), &err)
+
+	amenities, err := pos.setAmenities(ctx, amenityLinks{
+		lock:     "lock_room_type",
+		clear:    "clear_room_type_amenities",
+		add:      "add_room_type_amenities",
+		list:     "list_room_type_amenities",
+		notFound: ErrRoomTypeNotFound,
+	}, roomTypeId, codes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return amenities, nil
+}
+
+// amenityLinks names the statements that maintain the amenities of one kind
+// of owner, a hotel or a room type.
+type amenityLinks struct {
+	lock, clear, add, list string
+	notFound               error
+}
+
+// setAmenities replaces the amenities of the owner ownerId in one
+// transaction, holding the owner row so concurrent replacements serialise.
+func (pos *Postgres) setAmenities(ctx context.Context, links amenityLinks, ownerId int, codes []string) ([]models.Amenity, error) {
+	tx, err := pos.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin failed: %w", pgError(err))
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, links.lock, ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("lock failed: %w", pgError(err))
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, links.notFound
+	}
+
+	if _, err = tx.Exec(ctx, links.clear, ownerId); err != nil {
+		return nil, fmt.Errorf("delete failed: %w", pgError(err))
+	}
+
+	if _, err = tx.Exec(ctx, links.add, ownerId, AmenityCodes(codes)); err != nil {
+		if pgErrCode(err) == pgForeignKeyViolation {
+			return nil, ErrAmenityNotFound
+		}
+		return nil, fmt.Errorf("exec failed: %w", pgError(err))
+	}
+
+	amenities, err := queryAmenities(ctx, tx, links.list, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit failed: %w", pgError(err))
+	}
+
+	return amenities, nil
+}
+
+// querier is what pgxpool.Pool and pgx.Tx have in common for reads.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// queryAmenities runs a statement selecting amenityColumns.
+func queryAmenities(ctx context.Context, db querier, stmt string, args ...any) ([]models.Amenity, error) {
+	rows, err := db.Query(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", pgError(err))
+	}
+	defer rows.Close()
+
+	amenities := []models.Amenity{}
+	for rows.Next() {
+		a, err := scanAmenity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", pgError(err))
+		}
+		amenities = append(amenities, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows failed: %w", pgError(err))
+	}
+
+	return amenities, nil
+}
+
+func scanAmenity(row pgx.Row) (models.Amenity, error) {
+	var a models.Amenity
+	err := row.Scan(&a.Code, &a.Category, &a.Labels)
+	a.Labels = amenityLabels(a.Labels)
+	return a, err
+}
+
+// amenityLabels returns labels, or an empty map for nil so the labels column
+// and JSON responses hold an object rather than null.
+func amenityLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return map[string]string{}
+	}
+	return labels
+}
+
+// amenityWriteErr translates constraint violations raised by amenity writes.
+func amenityWriteErr(err error) error {
+	switch pgErrCode(err) {
+	case pgUniqueViolation:
+		return ErrAmenityExists
+	case pgCheckViolation:
+		return ErrAmenityInvalid
+	}
+	return pgError(err)
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/pricing"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AvailabilityFilter describes a stay to search for. Amenities keeps room
+// types offering every listed code, either themselves or through their hotel.
+type AvailabilityFilter struct {
+	City      string
+	Country   string
+	CheckIn   models.Date
+	CheckOut  models.Date
+	Guests    int
+	StarsMin  int
+	Amenities []string
+}
+
+// MaxStayNights bounds the stays that can be searched, quoted and booked.
+// Every night of a stay is counted and priced on its own.
+const MaxStayNights = 365
+
+// StayTooLong reports whether a stay from checkIn to checkOut has more than
+// MaxStayNights nights.
+func StayTooLong(checkIn, checkOut models.Date) bool {
+	return checkOut.Sub(checkIn.Time) > MaxStayNights*24*time.Hour
+}
+
+// SearchAvailability returns hotels with the room types that can host the
+// party, have a room free on every night of the stay and whose restrictions
+// allow it, each quoted under its cheapest rate plan. The query is
+// assembled from the filters that are actually set, so the planner can pick
+// hotels_location_idx/hotels_city_idx instead of falling back to a sequential
+// scan for "$1 IS NULL OR ..." predicates.
+func (pos *Postgres) SearchAvailability(ctx context.Context, filter AvailabilityFilter) (_ []models.HotelAvailability, err error) {
+	const op = "storage.postgres.SearchAvailability"
+	defer observe(ctx, op, time.Now(), &err)
+
+	args := []any{filter.CheckIn.Time, filter.CheckOut.Time, filter.Guests, filter.StarsMin}
+	where := []string{"rt.max_adults + rt.max_children >= $3", "h.stars >= $4"}
+
+	if filter.Country != "" {
+		args = append(args, filter.Country)
+		where = append(where, fmt.Sprintf("lower(h.country) = lower($%d)", len(args)))
+	}
+	if filter.City != "" {
+		args = append(args, filter.City)
+		where = append(where, fmt.Sprintf("lower(h.city) = lower($%d)", len(args)))
+	}
+	if codes := AmenityCodes(filter.Amenities); len(codes) > 0 {
+		args = append(args, codes)
+		where = append(where, offersAmenities("h.id", "rt.id", fmt.Sprintf("$%d", len(args))))
+	}
+
+	query := `SELECT * FROM (
+		SELECT h.id, h.country, h.city, h.hotel_name, h.stars,
+			rt.id, rt.hotel_id, rt.name, rt.description, rt.max_adults, rt.max_children, rt.bed_configuration,
+			rt.base_occupancy, rt.size_sqm,
+			` + roomTypeAvailable("rt.id", "$1", "$2") + ` AS available
+		FROM hotels h
+		JOIN room_types rt ON rt.hotel_id = h.id
+		WHERE ` + strings.Join(where, " AND ") + `
+	) candidates
+	WHERE available > 0
+	ORDER BY 1, 6`
+
+	rows, err := pos.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+	defer rows.Close()
+
+	hotels := []models.HotelAvailability{}
+	for rows.Next() {
+		var (
+			h  models.Hotel
+			rt models.RoomTypeAvailability
+		)
+
+		err := rows.Scan(&h.Id, &h.Country, &h.City, &h.HotelName, &h.Stars,
+			&rt.Id, &rt.HotelId, &rt.Name, &rt.Description, &rt.MaxAdults, &rt.MaxChildren, &rt.BedConfiguration,
+			&rt.BaseOccupancy, &rt.SizeSqm, &rt.Available)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan failed: %w", op, pgError(err))
+		}
+
+		if len(hotels) == 0 || hotels[len(hotels)-1].Id != h.Id {
+			hotels = append(hotels, models.HotelAvailability{Hotel: h})
+		}
+		last := &hotels[len(hotels)-1]
+		last.RoomTypes = append(last.RoomTypes, rt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows failed: %w", op, pgError(err))
+	}
+
+	var roomTypeIds []int
+	for _, h := range hotels {
+		for _, rt := range h.RoomTypes {
+			roomTypeIds = append(roomTypeIds, rt.Id)
+		}
+	}
+	if len(roomTypeIds) == 0 {
+		return hotels, nil
+	}
+
+	restrictions, err := queryRestrictions(ctx, pos.pool, roomTypeIds, filter.CheckIn, filter.CheckOut)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	restricted := make(map[int][]models.StayRestriction)
+	for _, r := range restrictions {
+		restricted[r.RoomTypeId] = append(restricted[r.RoomTypeId], r)
+	}
+
+	// Drop the room types the stay breaks a restriction of, and hotels left
+	// without any.
+	bookable := hotels[:0]
+	for _, h := range hotels {
+		var roomTypes []models.RoomTypeAvailability
+		for _, rt := range h.RoomTypes {
+			if CheckStay(restricted[rt.Id], filter.CheckIn, filter.CheckOut) == nil {
+				roomTypes = append(roomTypes, rt)
+			}
+		}
+		if len(roomTypes) > 0 {
+			h.RoomTypes = roomTypes
+			bookable = append(bookable, h)
+		}
+	}
+	hotels = bookable
+
+	plans, err := loadRatePlans(ctx, pos.pool, "list_rate_plans", roomTypeIds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byRoomType := make(map[int][]models.RatePlan)
+	for _, plan := range plans {
+		byRoomType[plan.RoomTypeId] = append(byRoomType[plan.RoomTypeId], plan)
+	}
+	for _, h := range hotels {
+		for i := range h.RoomTypes {
+			QuoteAvailability(&h.RoomTypes[i], byRoomType[h.RoomTypes[i].Id], filter)
+		}
+	}
+
+	return hotels, nil
+}
+
+// QuoteAvailability sets the quote of a search result to the cheapest price
+// of the stay under plans, the plans of its room type. A stay priced out of
+// range is listed without a quote.
+func QuoteAvailability(rt *models.RoomTypeAvailability, plans []models.RatePlan, filter AvailabilityFilter) {
+	if quote, ok, err := pricing.Cheapest(plans, rt.RoomType, filter.CheckIn, filter.CheckOut, filter.Guests); ok && err == nil {
+		rt.Quote = &quote
+	}
+}
+
+// offersAmenities returns an SQL condition that holds when the hotel hotelId
+// and its room type roomTypeId together offer every code of the text array
+// codes, which must not repeat a code.
+func offersAmenities(hotelId, roomTypeId, codes string) string {
+	return fmt.Sprintf(`(SELECT count(*) FROM (
+		SELECT amenity_code FROM hotel_amenities WHERE hotel_id = %[1]s
+		UNION
+		SELECT amenity_code FROM room_type_amenities WHERE room_type_id = %[2]s
+	) offered WHERE amenity_code = ANY(%[3]s::text[])) = cardinality(%[3]s::text[])`, hotelId, roomTypeId, codes)
+}
+
+// liveReservation returns an SQL condition that holds for the reservations r
+// that block their room type: neither cancelled nor expired, and not a hold
+// the sweeper has yet to mark expired.
+func liveReservation(r string) string {
+	return fmt.Sprintf(`%[1]s.status NOT IN ('cancelled', 'expired') AND (%[1]s.expires_at IS NULL OR %[1]s.expires_at > now())`, r)
+}
+
+// roomTypeAvailable returns an SQL expression for the number of rooms of the
+// type roomTypeId that are free on every night from checkIn to checkOut: its
+// rooms minus the live reservations of its busiest night.
+func roomTypeAvailable(roomTypeId, checkIn, checkOut string) string {
+	return fmt.Sprintf(`(SELECT count(*) FROM hotel_rooms WHERE room_type_id = %[1]s) - coalesce((
+		SELECT max(booked) FROM (
+			SELECT count(*) AS booked
+			FROM generate_series(0, %[3]s::date - %[2]s::date - 1) AS night
+			JOIN reservations r ON r.room_type_id = %[1]s
+				AND `+liveReservation("r")+`
+				AND r.check_in <= %[2]s::date + night
+				AND r.check_out > %[2]s::date + night
+			GROUP BY night
+		) nights
+	), 0)`, roomTypeId, checkIn, checkOut)
+}
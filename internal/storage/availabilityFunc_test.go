@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"bookings/internal/config"
+	"bookings/internal/migrations"
+	"bookings/internal/models"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	benchCities         = 50
+	benchHotelsPerCity  = 100
+	benchTypesPerHotel  = 4
+	benchRoomsPerHotel  = 20
+	benchVisitors       = 1000
+	benchReservationGap = 40
+)
+
+// BenchmarkSearchAvailability runs against BOOKINGS_BENCH_DATABASE_URL. The
+// database is truncated and reseeded, so point it at a scratch database only.
+func BenchmarkSearchAvailability(b *testing.B) {
+	url := os.Getenv("BOOKINGS_BENCH_DATABASE_URL")
+	if url == "" {
+		b.Skip("BOOKINGS_BENCH_DATABASE_URL is not set")
+	}
+
+	db, err := migrations.Open(url)
+	if err != nil {
+		b.Fatalf("open migrations db: %v", err)
+	}
+	err = migrations.Up(context.Background(), db)
+	db.Close()
+	if err != nil {
+		b.Fatalf("migrate: %v", err)
+	}
+
+	pos, err := NewPostgresDb(&config.Config{
+		DatabaseUrl:             url,
+		DatabaseMaxConns:        4,
+		DatabaseMaxConnLifetime: time.Hour,
+		DatabaseMaxConnIdleTime: time.Minute,
+	})
+	if err != nil {
+		b.Fatalf("failed to init storage: %v", err)
+	}
+	defer pos.Close()
+
+	base := time.Date(2030, time.July, 1, 0, 0, 0, 0, time.UTC)
+	seedAvailability(b, pos.pool, base)
+
+	filter := AvailabilityFilter{
+		City:     "city-7",
+		Country:  "country-7",
+		CheckIn:  models.NewDate(base.AddDate(0, 0, 10)),
+		CheckOut: models.NewDate(base.AddDate(0, 0, 14)),
+		Guests:   2,
+		StarsMin: 3,
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pos.SearchAvailability(context.Background(), filter); err != nil {
+			b.Fatalf("search failed: %v", err)
+		}
+	}
+}
+
+func seedAvailability(b *testing.B, pool *pgxpool.Pool, base time.Time) {
+	b.Helper()
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, `TRUNCATE reservations, visitors, hotel_rooms, stay_restrictions, rate_plans, room_types, hotels, amenities RESTART IDENTITY CASCADE`)
+	if err != nil {
+		b.Fatalf("truncate failed: %v", err)
+	}
+
+	hotels := make([][]any, 0, benchCities*benchHotelsPerCity)
+	for i := 0; i < benchCities*benchHotelsPerCity; i++ {
+		city := i % benchCities
+		hotels = append(hotels, []any{
+			fmt.Sprintf("country-%d", city), fmt.Sprintf("city-%d", city), fmt.Sprintf("hotel-%d", i), i%5 + 1,
+		})
+	}
+	copyRows(b, pool, "hotels", []string{"country", "city", "hotel_name", "stars"}, hotels)
+
+	roomTypes := make([][]any, 0, len(hotels)*benchTypesPerHotel)
+	for hotelId := 1; hotelId <= len(hotels); hotelId++ {
+		for j := 0; j < benchTypesPerHotel; j++ {
+			roomTypes = append(roomTypes, []any{hotelId, fmt.Sprintf("type-%d", j), 1 + j, 1})
+		}
+	}
+	copyRows(b, pool, "room_types", []string{"hotel_id", "name", "max_adults", "base_occupancy"}, roomTypes)
+
+	// Room j of a hotel is of the hotel's type j % benchTypesPerHotel.
+	rooms := make([][]any, 0, len(hotels)*benchRoomsPerHotel)
+	roomTypeIds := make([]int, 0, cap(rooms))
+	for hotelId := 1; hotelId <= len(hotels); hotelId++ {
+		for j := 0; j < benchRoomsPerHotel; j++ {
+			roomTypeId := (hotelId-1)*benchTypesPerHotel + j%benchTypesPerHotel + 1
+			rooms = append(rooms, []any{hotelId, roomTypeId, j + 1, j / 10})
+			roomTypeIds = append(roomTypeIds, roomTypeId)
+		}
+	}
+	copyRows(b, pool, "hotel_rooms", []string{"hotel_id", "room_type_id", "room_number", "floor"}, rooms)
+
+	visitors := make([][]any, 0, benchVisitors)
+	for i := 0; i < benchVisitors; i++ {
+		visitors = append(visitors, []any{1, 1, "Guest", fmt.Sprintf("Number %d", i), 18 + i%60})
+	}
+	copyRows(b, pool, "visitors", []string{"hotel_id", "hotel_room_id", "first_name", "last_name", "age"}, visitors)
+
+	// Two stays per room, booked by type without an assigned room.
+	reservations := make([][]any, 0, len(rooms)*2)
+	for roomId := 1; roomId <= len(rooms); roomId++ {
+		for _, offset := range []int{roomId % 30, roomId%30 + benchReservationGap} {
+			checkIn := base.AddDate(0, 0, offset)
+			reservations = append(reservations, []any{
+				roomTypeIds[roomId-1], 1 + roomId%benchVisitors, checkIn, checkIn.AddDate(0, 0, 3), 1, "confirmed",
+			})
+		}
+	}
+	copyRows(b, pool, "reservations", []string{"room_type_id", "visitor_id", "check_in", "check_out", "guests", "status"}, reservations)
+
+	if _, err := pool.Exec(ctx, `ANALYZE`); err != nil {
+		b.Fatalf("analyze failed: %v", err)
+	}
+}
+
+func copyRows(b *testing.B, pool *pgxpool.Pool, table string, columns []string, rows [][]any) {
+	b.Helper()
+
+	_, err := pool.CopyFrom(context.Background(), pgx.Identifier{table}, columns, pgx.CopyFromRows(rows))
+	if err != nil {
+		b.Fatalf("copy into %s failed: %v", table, err)
+	}
+}
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/pii"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Error categories. Every error returned by a repository that the caller can
+// act on wraps exactly one of them, so transports can pick a status code with
+// errors.Is without knowing the concrete entity.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrValidation  = errors.New("validation failed")
+	ErrUnavailable = errors.New("storage unavailable")
+)
+
+// Error is a domain error: a message that is safe to show to clients and the
+// category it belongs to. Validation errors may list the offending fields.
+type Error struct {
+	Kind   error
+	Msg    string
+	Fields []FieldError
+}
+
+// FieldError describes one input field that failed a validation rule.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// NewError returns a domain error of the given category.
+func NewError(kind error, msg string) *Error {
+	return &Error{Kind: kind, Msg: msg}
+}
+
+// NewFieldError returns a validation error for the given failed fields.
+func NewFieldError(fields []FieldError) *Error {
+	msgs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		msgs = append(msgs, f.Field+" "+f.Message)
+	}
+
+	return &Error{Kind: ErrValidation, Msg: strings.Join(msgs, "; "), Fields: fields}
+}
+
+func (e *Error) Error() string { return e.Msg }
+
+func (e *Error) Unwrap() error { return e.Kind }
+
+var (
+	ErrHotelNotFound = NewError(ErrNotFound, "hotel not found")
+	ErrHotelExists   = NewError(ErrConflict, "hotel already exists")
+	ErrHotelHasRooms = NewError(ErrConflict, "hotel still has rooms or room types")
+	ErrHotelInvalid  = NewError(ErrValidation, "hotel stars must be between 1 and 5")
+
+	ErrRoomTypeNotFound        = NewError(ErrNotFound, "room type not found")
+	ErrRoomTypeExists          = NewError(ErrConflict, "hotel already has a room type with this name")
+	ErrRoomTypeInvalid         = NewError(ErrValidation, "room type needs at least one adult and a base occupancy within its capacity")
+	ErrRoomTypeHasRooms        = NewError(ErrConflict, "room type still has rooms")
+	ErrRoomTypeHasReservations = NewError(ErrConflict, "room type still has reservations")
+	ErrRoomTypeSoldOut         = NewError(ErrConflict, "no room of this type is free for these dates")
+
+	ErrRatePlanNotFound        = NewError(ErrNotFound, "rate plan not found")
+	ErrRatePlanExists          = NewError(ErrConflict, "room type already has a rate plan with this name")
+	ErrRatePlanInvalid         = NewError(ErrValidation, fmt.Sprintf("rate plan needs an ISO 4217 currency, a positive base price and prices from 0 to %s", models.MaxPrice))
+	ErrRatePlanCurrency        = NewError(ErrValidation, "only currencies with two decimal places are supported")
+	ErrRatePlanSeasonInvalid   = NewError(ErrValidation, fmt.Sprintf("seasons need a positive price of at most %s, must not end before they start and must not overlap", models.MaxPrice))
+	ErrRatePlanDiscountInvalid = NewError(ErrValidation, "stay discounts need at least 2 nights, a percentage between 1 and 100 and distinct lengths")
+	ErrRatePlanHasReservations = NewError(ErrConflict, "rate plan still has reservations")
+	ErrRatePlanNotOffered      = NewError(ErrValidation, "room type has no such rate plan")
+	ErrStayPriceOverflow       = NewError(ErrValidation, "the price of the stay is too large")
+	ErrRoomTypeNotPriced       = NewError(ErrNotFound, "room type has no rate plans")
+
+	ErrRestrictionRangeInvalid = NewError(ErrValidation, "restriction range needs from and to, must not end before it starts and may span at most 366 days")
+	ErrRestrictionInvalid      = NewError(ErrValidation, "stay lengths must not be negative and the maximum stay must not be shorter than the minimum")
+	ErrStayRestricted          = NewError(ErrConflict, "stay is not bookable under the restrictions of the room type")
+
+	ErrAmenityNotFound = NewError(ErrNotFound, "amenity not found")
+	ErrAmenityExists   = NewError(ErrConflict, "amenity already exists")
+	ErrAmenityInvalid  = NewError(ErrValidation, "amenity code must be a lower-case identifier and category must be set")
+	ErrAmenityInUse    = NewError(ErrConflict, "amenity is still offered by hotels or room types")
+
+	ErrHotelRoomNotFound        = NewError(ErrNotFound, "hotel room not found")
+	ErrHotelRoomInvalid         = NewError(ErrValidation, "hotel room number must be positive")
+	ErrHotelRoomExists          = NewError(ErrConflict, "hotel already has a room with this number")
+	ErrHotelRoomHasVisitors     = NewError(ErrConflict, "hotel room still has visitors")
+	ErrHotelRoomHasReservations = NewError(ErrConflict, "hotel room still has reservations")
+
+	ErrVisitorNotFound        = NewError(ErrNotFound, "visitor not found")
+	ErrVisitorInvalidAge      = NewError(ErrValidation, "visitor age must be between 18 and 100")
+	ErrVisitorHotelMismatch   = NewError(ErrValidation, "hotel room belongs to another hotel")
+	ErrVisitorHasReservations = NewError(ErrConflict, "visitor still has reservations")
+
+	ErrReservationNotFound       = NewError(ErrNotFound, "reservation not found")
+	ErrReservationInvalid        = NewError(ErrValidation, fmt.Sprintf("check_out must be after check_in and at most %d nights later, and guests must be positive", MaxStayNights))
+	ErrReservationNotCancellable = NewError(ErrConflict, "reservation can no longer be cancelled")
+	ErrReservationNotCheckable   = NewError(ErrConflict, "only pending or confirmed reservations can be checked in")
+	ErrReservationNotConfirmable = NewError(ErrConflict, "only held or pending reservations can be confirmed")
+	ErrHoldExpired               = NewError(ErrConflict, "hold has expired")
+	ErrHoldInvalid               = NewError(ErrValidation, "hold duration must be positive")
+	ErrReservationTooManyGuests  = NewError(ErrValidation, "room type cannot host that many guests")
+	ErrRoomTypeMismatch          = NewError(ErrValidation, "hotel room is not of the reserved room type")
+	ErrRoomAlreadyBooked         = NewError(ErrConflict, "hotel room is already booked for these dates")
+)
+
+// pgError sorts a pgx error that no caller translated into a more specific
+// domain error into one of the categories. The original error stays in the
+// chain for logging, with the values postgres echoes scrubbed.
+func pgError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, pgx.ErrNoRows):
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	if pgErrCode(err) != "" {
+		err = scrubbedError{err}
+	}
+
+	switch code := pgErrCode(err); {
+	case code == pgUniqueViolation, code == pgExclusionViolation, code == pgForeignKeyViolation:
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	case code == pgCheckViolation, code == pgNotNullViolation, strings.HasPrefix(code, pgDataExceptionClass):
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	if pgUnavailable(err) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	return err
+}
+
+// scrubbedError hides the parameter values postgres repeats in its messages,
+// which may be personal data. errors.Is and errors.As still see the original.
+type scrubbedError struct {
+	err error
+}
+
+func (e scrubbedError) Error() string { return pii.ScrubText(e.err.Error()) }
+
+func (e scrubbedError) Unwrap() error { return e.err }
+
+// pgUnavailable reports whether err means the database could not be reached
+// or refused to serve the query, as opposed to rejecting the query itself.
+func pgUnavailable(err error) bool {
+	var connErr *pgconn.ConnectError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		return true
+	case errors.As(err, &connErr), errors.As(err, &netErr):
+		return true
+	}
+
+	switch code := pgErrCode(err); {
+	case strings.HasPrefix(code, pgConnectionExceptionClass),
+		strings.HasPrefix(code, pgInsufficientResourcesClass),
+		strings.HasPrefix(code, pgOperatorInterventionClass):
+		return true
+	}
+
+	return false
+}
+
+// pgConstraint returns the name of the constraint a postgres error refers to.
+func pgConstraint(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
+
+// pgErrCode returns the SQLSTATE of a postgres error or an empty string.
+func pgErrCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgExclusionViolation  = "23P01"
+	pgNotNullViolation    = "23502"
+
+	pgDataExceptionClass         = "22"
+	pgConnectionExceptionClass   = "08"
+	pgInsufficientResourcesClass = "53"
+	pgOperatorInterventionClass  = "57"
+)
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestPgError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"no rows", pgx.ErrNoRows, ErrNotFound},
+		{"wrapped no rows", fmt.Errorf("scan: %w", pgx.ErrNoRows), ErrNotFound},
+		{"unique", &pgconn.PgError{Code: pgUniqueViolation}, ErrConflict},
+		{"exclusion", &pgconn.PgError{Code: pgExclusionViolation}, ErrConflict},
+		{"foreign key", &pgconn.PgError{Code: pgForeignKeyViolation}, ErrConflict},
+		{"check", &pgconn.PgError{Code: pgCheckViolation}, ErrValidation},
+		{"not null", &pgconn.PgError{Code: pgNotNullViolation}, ErrValidation},
+		{"invalid date", &pgconn.PgError{Code: "22007"}, ErrValidation},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, ErrUnavailable},
+		{"too many connections", &pgconn.PgError{Code: "53300"}, ErrUnavailable},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, ErrUnavailable},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pgError(tt.err)
+			if !errors.Is(got, tt.want) {
+				t.Fatalf("pgError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Fatalf("pgError(%v) dropped the original error", tt.err)
+			}
+		})
+	}
+
+	syntax := &pgconn.PgError{Code: "42601"}
+	got := pgError(syntax)
+	for _, kind := range []error{ErrNotFound, ErrConflict, ErrValidation, ErrUnavailable} {
+		if errors.Is(got, kind) {
+			t.Fatalf("pgError(syntax error) = %v, want it uncategorized", got)
+		}
+	}
+	if !errors.Is(got, syntax) {
+		t.Fatalf("pgError(syntax error) dropped the original error")
+	}
+}
+
+func TestPgErrorScrubsValues(t *testing.T) {
+	pgErr := &pgconn.PgError{Code: "22P02", Message: `invalid input syntax for type integer: "John Smith"`}
+
+	got := pgError(fmt.Errorf("scan: %w", pgErr))
+	if strings.Contains(got.Error(), "John") {
+		t.Errorf("pgError leaks the value: %v", got)
+	}
+	if !errors.Is(got, ErrValidation) {
+		t.Errorf("pgError(%v) = %v, want validation", pgErr, got)
+	}
+
+	var unwrapped *pgconn.PgError
+	if !errors.As(got, &unwrapped) || unwrapped.Code != "22P02" {
+		t.Errorf("pgError dropped the postgres error from the chain")
+	}
+}
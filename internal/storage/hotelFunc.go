@@ -3,93 +3,161 @@ package storage
 import (
 	"bookings/internal/models"
 	"context"
-	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
-func (pos *Postgres) CreateHotel(country string, city string, hotelName string, stars int) error {
+func (pos *Postgres) CreateHotel(ctx context.Context, country string, city string, hotelName string, stars int) (_ models.Hotel, err error) {
 	const op = "storage.postgres.CreateHotel"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
+
+	hotel := models.Hotel{Country: country, City: city, HotelName: hotelName, Stars: stars}
 
-	_, err := pos.conn.Exec(ctx, "create_hotel", country, city, hotelName, stars)
+	err = pos.pool.QueryRow(ctx, "create_hotel", country, city, hotelName, stars).Scan(&hotel.Id)
 	if err != nil {
-		return fmt.Errorf("%s: exec failed: %w", op, err)
+		return models.Hotel{}, fmt.Errorf("%s: exec failed: %w", op, hotelWriteErr(err))
 	}
 
-	return nil
+	return hotel, nil
+}
+
+// HotelFilter selects hotels for ListHotels. Zero fields do not filter;
+// Country and City match case-insensitively, NamePrefix the start of the name.
+// Amenities keeps hotels offering every listed code, see AmenityCodes.
+type HotelFilter struct {
+	PageRequest
+	Country    string   `form:"country"`
+	City       string   `form:"city"`
+	NamePrefix string   `form:"name"`
+	StarsMin   int      `form:"stars_min" binding:"omitempty,min=1,max=5"`
+	StarsMax   int      `form:"stars_max" binding:"omitempty,min=1,max=5,gtefield=StarsMin"`
+	Amenities  []string `form:"amenities"`
+}
+
+// HotelSortFields are the fields hotels can be sorted by.
+var HotelSortFields = map[string]SortField[models.Hotel]{
+	"id":         {Column: "id", Value: func(h models.Hotel) any { return h.Id }},
+	"country":    {Column: "country", Value: func(h models.Hotel) any { return h.Country }},
+	"city":       {Column: "city", Value: func(h models.Hotel) any { return h.City }},
+	"hotel_name": {Column: "hotel_name", Value: func(h models.Hotel) any { return h.HotelName }},
+	"stars":      {Column: "stars", Value: func(h models.Hotel) any { return h.Stars }},
 }
 
-func (pos *Postgres) GetAllHotels() (string, error) {
-	const op = "storage.postgres.GetAllHotels"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+func (pos *Postgres) ListHotels(ctx context.Context, filter HotelFilter) (_ models.Page[models.Hotel], err error) {
+	const op = "storage.postgres.ListHotels"
+	defer observe(ctx, op, time.Now(), &err)
 
-	rows, err := pos.conn.Query(ctx, "get_all_hotels")
+	k, err := NewKeyset(filter.PageRequest, HotelSortFields, "id")
 	if err != nil {
-		return "", fmt.Errorf("%s: scan failed: %w", op, err)
+		return models.Page[models.Hotel]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var hotels []models.Hotel
+	var q listQuery
+	if filter.Country != "" {
+		q.filter("lower(country) = lower(%s)", filter.Country)
+	}
+	if filter.City != "" {
+		q.filter("lower(city) = lower(%s)", filter.City)
+	}
+	if filter.NamePrefix != "" {
+		q.filter("lower(hotel_name) LIKE lower(%s)", prefixPattern(filter.NamePrefix))
+	}
+	if filter.StarsMin != 0 {
+		q.filter("stars >= %s", filter.StarsMin)
+	}
+	if filter.StarsMax != 0 {
+		q.filter("stars <= %s", filter.StarsMax)
+	}
+	if codes := AmenityCodes(filter.Amenities); len(codes) > 0 {
+		q.filter(`id IN (SELECT hotel_id FROM hotel_amenities WHERE amenity_code = ANY(%s::text[])
+			GROUP BY hotel_id HAVING count(*) = cardinality(%s::text[]))`, codes)
+	}
+
+	query := build(&q, "SELECT id, country, city, hotel_name, stars FROM hotels", k)
+
+	rows, err := pos.pool.Query(ctx, query, q.args...)
+	if err != nil {
+		return models.Page[models.Hotel]{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+	defer rows.Close()
+
+	hotels := []models.Hotel{}
 	for rows.Next() {
 		var h models.Hotel
-		if err := rows.Scan(&h.Country, &h.City, &h.HotelName, &h.Stars); err != nil {
-			return "", fmt.Errorf("%s: scan failed: %w", op, err)
+		if err := rows.Scan(&h.Id, &h.Country, &h.City, &h.HotelName, &h.Stars); err != nil {
+			return models.Page[models.Hotel]{}, fmt.Errorf("%s: scan failed: %w", op, pgError(err))
 		}
 		hotels = append(hotels, h)
 	}
-
-	jsonData, err := json.Marshal(hotels)
-	if err != nil {
-		return "", fmt.Errorf("%s: marshal failed: %w", op, err)
+	if err := rows.Err(); err != nil {
+		return models.Page[models.Hotel]{}, fmt.Errorf("%s: rows failed: %w", op, pgError(err))
 	}
 
-	return string(jsonData), nil
+	return k.Page(hotels), nil
 }
 
-func (pos *Postgres) GetHotel(id int) (string, error) {
+func (pos *Postgres) GetHotel(ctx context.Context, id int) (_ models.Hotel, err error) {
 	const op = "storage.postgres.GetHotel"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
 	var hotel models.Hotel
-	err := pos.conn.QueryRow(ctx, "get_hotel", id).Scan(&hotel.Id, &hotel.Country, &hotel.City, &hotel.HotelName, &hotel.Stars)
+	err = pos.pool.QueryRow(ctx, "get_hotel", id).Scan(&hotel.Id, &hotel.Country, &hotel.City, &hotel.HotelName, &hotel.Stars)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", fmt.Errorf("%s: not found: %w", op, err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Hotel{}, fmt.Errorf("%s: %w", op, ErrHotelNotFound)
 		}
-		return "", fmt.Errorf("%s: query failed: %w", op, err)
+		return models.Hotel{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
 	}
 
-	jsonHotel, _ := json.Marshal(hotel)
-
-	return string(jsonHotel), nil
+	return hotel, nil
 }
 
-func (pos *Postgres) DeleteHotel(id int) error {
+func (pos *Postgres) DeleteHotel(ctx context.Context, id int) (err error) {
 	const op = "storage.postgres.DeleteHotel"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
-	_, err := pos.conn.Exec(ctx, "delete_hotel", id)
+	tag, err := pos.pool.Exec(ctx, "delete_hotel", id)
 	if err != nil {
-		return fmt.Errorf("%s: delete failed: %w", op, err)
+		// Rooms and room types restrict deleting their hotel.
+		if pgErrCode(err) == pgForeignKeyViolation {
+			return fmt.Errorf("%s: %w", op, ErrHotelHasRooms)
+		}
+		return fmt.Errorf("%s: delete failed: %w", op, pgError(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrHotelNotFound)
 	}
 
 	return nil
 }
 
-func (pos *Postgres) UpdateHotel(id int, country string, city string, hotelName string, stars int) (string, error) {
+func (pos *Postgres) UpdateHotel(ctx context.Context, id int, country string, city string, hotelName string, stars int) (_ models.Hotel, err error) {
 	const op = "storage.postgres.UpdateHotel"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
-	_, err := pos.conn.Exec(ctx, "update_hotel", country, city, hotelName, stars, id)
+	tag, err := pos.pool.Exec(ctx, "update_hotel", country, city, hotelName, stars, id)
 	if err != nil {
-		return "", fmt.Errorf("%s: update failed: %w", op, err)
+		return models.Hotel{}, fmt.Errorf("%s: update failed: %w", op, hotelWriteErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.Hotel{}, fmt.Errorf("%s: %w", op, ErrHotelNotFound)
 	}
 
-	return pos.GetHotel(id)
+	return models.Hotel{Id: id, Country: country, City: city, HotelName: hotelName, Stars: stars}, nil
+}
+
+// hotelWriteErr translates constraint violations raised by hotel writes.
+func hotelWriteErr(err error) error {
+	switch pgErrCode(err) {
+	case pgUniqueViolation:
+		return ErrHotelExists
+	case pgCheckViolation:
+		return ErrHotelInvalid
+	}
+	return pgError(err)
 }
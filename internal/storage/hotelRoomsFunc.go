@@ -3,94 +3,169 @@ package storage
 import (
 	"bookings/internal/models"
 	"context"
-	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
-func (pos *Postgres) CreateHotelRoom(hotelId int, rooms int, meals bool, bar bool, service bool, busy bool) error {
+func (pos *Postgres) CreateHotelRoom(ctx context.Context, hotelId int, roomTypeId int, roomNumber int, floor int) (_ models.HotelRoom, err error) {
 	const op = "storage.postgres.CreateHotelRoom"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
-	_, err := pos.conn.Exec(ctx, "create_hotel_room", hotelId, rooms, meals, bar, service, busy)
+	var id int
+	err = pos.pool.QueryRow(ctx, "create_hotel_room", hotelId, roomTypeId, roomNumber, floor).Scan(&id)
 	if err != nil {
-		return fmt.Errorf("%s: exec failed: %w", op, err)
+		return models.HotelRoom{}, fmt.Errorf("%s: exec failed: %w", op, hotelRoomWriteErr(err))
 	}
-	return nil
+
+	return pos.GetHotelRoom(ctx, id)
 }
 
-func (pos *Postgres) GetAllHotelRooms() (string, error) {
-	const op = "storage.postgres.GetAllHotelRooms"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// HotelRoomFilter selects rooms for ListHotelRooms. Zero fields do not filter;
+// GuestsMin keeps rooms whose type can host at least that many guests.
+type HotelRoomFilter struct {
+	PageRequest
+	HotelId    int `form:"hotel_id" binding:"omitempty,gt=0"`
+	RoomTypeId int `form:"room_type_id" binding:"omitempty,gt=0"`
+	GuestsMin  int `form:"guests_min" binding:"omitempty,gt=0"`
+}
 
-	rows, err := pos.conn.Query(ctx, "get_all_hotel_rooms")
+// HotelRoomSortFields are the fields rooms can be sorted by.
+var HotelRoomSortFields = map[string]SortField[models.HotelRoom]{
+	"id":          {Column: "id", Value: func(hr models.HotelRoom) any { return hr.Id }},
+	"hotel_id":    {Column: "hotel_id", Value: func(hr models.HotelRoom) any { return hr.HotelId }},
+	"room_number": {Column: "room_number", Value: func(hr models.HotelRoom) any { return hr.RoomNumber }},
+	"floor":       {Column: "floor", Value: func(hr models.HotelRoom) any { return hr.Floor }},
+}
+
+func (pos *Postgres) ListHotelRooms(ctx context.Context, filter HotelRoomFilter) (_ models.Page[models.HotelRoom], err error) {
+	const op = "storage.postgres.ListHotelRooms"
+	defer observe(ctx, op, time.Now(), &err)
+
+	k, err := NewKeyset(filter.PageRequest, HotelRoomSortFields, "id")
 	if err != nil {
-		return "", fmt.Errorf("%s: query failed: %w", op, err)
+		return models.Page[models.HotelRoom]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var hotelRooms []models.HotelRoom
+	var q listQuery
+	if filter.HotelId != 0 {
+		q.filter("hotel_id = %s", filter.HotelId)
+	}
+	if filter.RoomTypeId != 0 {
+		q.filter("room_type_id = %s", filter.RoomTypeId)
+	}
+	if filter.GuestsMin != 0 {
+		q.filter("room_type_id IN (SELECT id FROM room_types WHERE max_adults + max_children >= %s)", filter.GuestsMin)
+	}
+
+	query := build(&q, "SELECT id, hotel_id, room_type_id, room_number, floor FROM hotel_rooms", k)
+
+	rows, err := pos.pool.Query(ctx, query, q.args...)
+	if err != nil {
+		return models.Page[models.HotelRoom]{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+
+	hotelRooms, err := scanHotelRooms(op, rows)
+	if err != nil {
+		return models.Page[models.HotelRoom]{}, err
+	}
+
+	return k.Page(hotelRooms), nil
+}
+
+func scanHotelRooms(op string, rows pgx.Rows) ([]models.HotelRoom, error) {
+	defer rows.Close()
+
+	hotelRooms := []models.HotelRoom{}
 	for rows.Next() {
 		var hr models.HotelRoom
 
-		if err = rows.Scan(&hr.Id, &hr.HotelId, &hr.Rooms, &hr.Meals, &hr.Bar, &hr.Services, &hr.Busy); err != nil {
-			return "", fmt.Errorf("%s: scan failed: %w", op, err)
+		if err := rows.Scan(&hr.Id, &hr.HotelId, &hr.RoomTypeId, &hr.RoomNumber, &hr.Floor); err != nil {
+			return nil, fmt.Errorf("%s: scan failed: %w", op, pgError(err))
 		}
 
 		hotelRooms = append(hotelRooms, hr)
 	}
-
-	jsonHR, err := json.Marshal(hotelRooms)
-	if err != nil {
-		return "", fmt.Errorf("%s: marshal failed: %w", op, err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows failed: %w", op, pgError(err))
 	}
 
-	return string(jsonHR), nil
+	return hotelRooms, nil
 }
 
-func (pos *Postgres) GetHotelRoom(id int) (string, error) {
+func (pos *Postgres) GetHotelRoom(ctx context.Context, id int) (_ models.HotelRoom, err error) {
 	const op = "storage.postgres.GetHotelRoom"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
 	var hr models.HotelRoom
-	err := pos.conn.QueryRow(ctx, "get_hotel_room", id).Scan(&hr.Id, &hr.HotelId, &hr.Rooms, &hr.Meals, &hr.Bar, &hr.Services, &hr.Busy)
+	err = pos.pool.QueryRow(ctx, "get_hotel_room", id).Scan(&hr.Id, &hr.HotelId, &hr.RoomTypeId, &hr.RoomNumber, &hr.Floor)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", fmt.Errorf("%s: not found: %w", op, err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.HotelRoom{}, fmt.Errorf("%s: %w", op, ErrHotelRoomNotFound)
 		}
-		return "", fmt.Errorf("%s: query failed: %w", op, err)
+		return models.HotelRoom{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
 	}
 
-	jsonHR, _ := json.Marshal(hr)
-
-	return string(jsonHR), nil
+	return hr, nil
 }
 
-func (pos *Postgres) DeleteHotelRoom(id int) error {
+func (pos *Postgres) DeleteHotelRoom(ctx context.Context, id int) (err error) {
 	const op = "storage.postgres.DeleteHotelRoom"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
-	_, err := pos.conn.Exec(ctx, "delete_hotel_room", id)
+	tag, err := pos.pool.Exec(ctx, "delete_hotel_room", id)
 	if err != nil {
-		return fmt.Errorf("%s: delete failed: %w", op, err)
+		if pgErrCode(err) == pgForeignKeyViolation {
+			if pgConstraint(err) == "reservations_hotel_room_fkey" {
+				return fmt.Errorf("%s: %w", op, ErrHotelRoomHasReservations)
+			}
+			return fmt.Errorf("%s: %w", op, ErrHotelRoomHasVisitors)
+		}
+		return fmt.Errorf("%s: delete failed: %w", op, pgError(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrHotelRoomNotFound)
 	}
 
 	return nil
 }
 
-func (pos *Postgres) UpdateHotelRoom(id int, hotelId int, rooms int, meals bool, bar bool, service bool) (string, error) {
+func (pos *Postgres) UpdateHotelRoom(ctx context.Context, id int, hotelId int, roomTypeId int, roomNumber int, floor int) (_ models.HotelRoom, err error) {
 	const op = "storage.postgres.UpdateHotelRoom"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
-	_, err := pos.conn.Exec(ctx, "update_hotel", hotelId, rooms, meals, bar, service, id)
+	tag, err := pos.pool.Exec(ctx, "update_hotel_room", hotelId, roomTypeId, roomNumber, floor, id)
 	if err != nil {
-		return "", fmt.Errorf("%s: update failed: %w", op, err)
+		return models.HotelRoom{}, fmt.Errorf("%s: update failed: %w", op, hotelRoomWriteErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.HotelRoom{}, fmt.Errorf("%s: %w", op, ErrHotelRoomNotFound)
 	}
 
-	return pos.GetHotelRoom(id)
+	return pos.GetHotelRoom(ctx, id)
+}
+
+// hotelRoomWriteErr translates constraint violations raised by hotel room
+// writes. A room type of another hotel violates the composite type key just
+// like a missing one.
+func hotelRoomWriteErr(err error) error {
+	switch pgErrCode(err) {
+	case pgForeignKeyViolation:
+		switch pgConstraint(err) {
+		case "hotel_rooms_room_type_fkey":
+			return ErrRoomTypeNotFound
+		case "reservations_hotel_room_fkey":
+			return ErrHotelRoomHasReservations
+		}
+		return ErrHotelNotFound
+	case pgCheckViolation:
+		return ErrHotelRoomInvalid
+	case pgUniqueViolation:
+		return ErrHotelRoomExists
+	}
+	return pgError(err)
 }
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// PageRequest selects one page of a list. Sort names a whitelisted field,
+// prefixed with "-" for descending order; Cursor is the next_cursor of the
+// previous page and is only valid with the same Sort.
+type PageRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort"`
+}
+
+// SortField is a field a list can be ordered by: the column it maps to in
+// postgres and how to read it from a model. Ties are broken by id.
+type SortField[T any] struct {
+	Column string
+	Value  func(T) any
+}
+
+// Keyset is a validated PageRequest for one list. After is the sort value
+// and id of the last row of the previous page, if any.
+type Keyset[T any] struct {
+	Sort    string
+	Field   SortField[T]
+	IdField SortField[T]
+	Desc    bool
+	Limit   int
+
+	After    any
+	AfterId  int
+	HasAfter bool
+}
+
+type cursor struct {
+	Sort  string          `json:"s"`
+	Value json.RawMessage `json:"v"`
+	Id    int             `json:"id"`
+}
+
+// NewKeyset validates page against the sortable fields of a list. fields
+// must contain "id"; defaultSort is used when page.Sort is empty.
+func NewKeyset[T any](page PageRequest, fields map[string]SortField[T], defaultSort string) (Keyset[T], error) {
+	k := Keyset[T]{Sort: page.Sort, IdField: fields["id"], Limit: page.Limit}
+
+	if k.Sort == "" {
+		k.Sort = defaultSort
+	}
+	if k.Limit == 0 {
+		k.Limit = DefaultPageLimit
+	}
+	if k.Limit < 1 || k.Limit > MaxPageLimit {
+		return k, NewFieldError([]FieldError{{
+			Field:   "limit",
+			Rule:    "max",
+			Param:   fmt.Sprint(MaxPageLimit),
+			Message: fmt.Sprintf("must be between 1 and %d", MaxPageLimit),
+		}})
+	}
+
+	name, desc := strings.CutPrefix(k.Sort, "-")
+	field, ok := fields[name]
+	if !ok {
+		names := slices.Sorted(maps.Keys(fields))
+		return k, NewFieldError([]FieldError{{
+			Field:   "sort",
+			Rule:    "oneof",
+			Param:   strings.Join(names, " "),
+			Message: "must be one of: " + strings.Join(names, ", "),
+		}})
+	}
+	k.Field, k.Desc = field, desc
+
+	if page.Cursor == "" {
+		return k, nil
+	}
+
+	if err := k.decodeCursor(page.Cursor); err != nil {
+		return k, NewFieldError([]FieldError{{
+			Field:   "cursor",
+			Rule:    "cursor",
+			Message: err.Error(),
+		}})
+	}
+
+	return k, nil
+}
+
+func (k *Keyset[T]) decodeCursor(s string) error {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("is malformed")
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return fmt.Errorf("is malformed")
+	}
+	if c.Sort != k.Sort {
+		return fmt.Errorf("was issued for sort %q", c.Sort)
+	}
+
+	var zero T
+	value := reflect.New(reflect.TypeOf(k.Field.Value(zero)))
+	if err := json.Unmarshal(c.Value, value.Interface()); err != nil {
+		return fmt.Errorf("is malformed")
+	}
+
+	k.After, k.AfterId, k.HasAfter = value.Elem().Interface(), c.Id, true
+	return nil
+}
+
+// Page turns the rows of one page, fetched with a limit of k.Limit+1, into
+// the response envelope with the cursor of the following page.
+func (k Keyset[T]) Page(rows []T) models.Page[T] {
+	if len(rows) <= k.Limit {
+		return models.Page[T]{Items: rows}
+	}
+
+	rows = rows[:k.Limit]
+	last := rows[len(rows)-1]
+
+	value, _ := json.Marshal(k.Field.Value(last))
+	raw, _ := json.Marshal(cursor{Sort: k.Sort, Value: value, Id: k.IdField.Value(last).(int)})
+
+	return models.Page[T]{Items: rows, NextCursor: base64.RawURLEncoding.EncodeToString(raw)}
+}
+
+// listQuery assembles the WHERE clause and arguments of a list query from the
+// filters that are set, in the same way SearchAvailability does.
+type listQuery struct {
+	args  []any
+	where []string
+}
+
+// filter adds a condition; every %s in cond is replaced by the placeholder of
+// arg.
+func (q *listQuery) filter(cond string, arg any) {
+	q.args = append(q.args, arg)
+	placeholder := fmt.Sprintf("$%d", len(q.args))
+	q.where = append(q.where, strings.ReplaceAll(cond, "%s", placeholder))
+}
+
+// build returns the query for one keyset page of base, which must be a
+// SELECT without WHERE, ORDER BY and LIMIT.
+func build[T any](q *listQuery, base string, k Keyset[T]) string {
+	order, cmp := "ASC", ">"
+	if k.Desc {
+		order, cmp = "DESC", "<"
+	}
+
+	if k.HasAfter {
+		q.args = append(q.args, k.After, k.AfterId)
+		q.where = append(q.where, fmt.Sprintf("(%s, %s) %s ($%d, $%d)",
+			k.Field.Column, k.IdField.Column, cmp, len(q.args)-1, len(q.args)))
+	}
+
+	query := base
+	if len(q.where) > 0 {
+		query += "\n\tWHERE " + strings.Join(q.where, " AND ")
+	}
+
+	return query + fmt.Sprintf("\n\tORDER BY %s %s, %s %s\n\tLIMIT %d",
+		k.Field.Column, order, k.IdField.Column, order, k.Limit+1)
+}
+
+// prefixPattern escapes s for use as a LIKE prefix.
+func prefixPattern(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s) + "%"
+}
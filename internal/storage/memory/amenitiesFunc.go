@@ -0,0 +1,221 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+)
+
+// amenityCode mirrors amenities_code_check.
+var amenityCode = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+func (m *Memory) CreateAmenity(ctx context.Context, a models.Amenity) (models.Amenity, error) {
+	const op = "storage.memory.CreateAmenity"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !amenityCode.MatchString(a.Code) || a.Category == "" {
+		return models.Amenity{}, fmt.Errorf("%s: exec failed: %w", op, storage.ErrAmenityInvalid)
+	}
+
+	if _, ok := m.amenities[a.Code]; ok {
+		return models.Amenity{}, fmt.Errorf("%s: exec failed: %w", op, storage.ErrAmenityExists)
+	}
+
+	a.Labels = cloneLabels(a.Labels)
+	m.amenities[a.Code] = a
+
+	return a, nil
+}
+
+func (m *Memory) ListAmenities(ctx context.Context, filter storage.AmenityFilter) ([]models.Amenity, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.sortedAmenities(func(a models.Amenity) bool {
+		return filter.Category == "" || a.Category == filter.Category
+	}), nil
+}
+
+func (m *Memory) GetAmenity(ctx context.Context, code string) (models.Amenity, error) {
+	const op = "storage.memory.GetAmenity"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	a, ok := m.amenities[code]
+	if !ok {
+		return models.Amenity{}, fmt.Errorf("%s: %w", op, storage.ErrAmenityNotFound)
+	}
+
+	a.Labels = cloneLabels(a.Labels)
+	return a, nil
+}
+
+func (m *Memory) UpdateAmenity(ctx context.Context, code string, a models.Amenity) (models.Amenity, error) {
+	const op = "storage.memory.UpdateAmenity"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.amenities[code]; !ok {
+		return models.Amenity{}, fmt.Errorf("%s: %w", op, storage.ErrAmenityNotFound)
+	}
+
+	if a.Category == "" {
+		return models.Amenity{}, fmt.Errorf("%s: update failed: %w", op, storage.ErrAmenityInvalid)
+	}
+
+	a.Code, a.Labels = code, cloneLabels(a.Labels)
+	m.amenities[code] = a
+
+	return a, nil
+}
+
+func (m *Memory) DeleteAmenity(ctx context.Context, code string) error {
+	const op = "storage.memory.DeleteAmenity"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.amenities[code]; !ok {
+		return fmt.Errorf("%s: %w", op, storage.ErrAmenityNotFound)
+	}
+
+	for _, offered := range [](map[int]map[string]bool){m.hotelAmenities, m.roomTypeAmenities} {
+		for _, codes := range offered {
+			if codes[code] {
+				return fmt.Errorf("%s: %w", op, storage.ErrAmenityInUse)
+			}
+		}
+	}
+
+	delete(m.amenities, code)
+
+	return nil
+}
+
+func (m *Memory) ListHotelAmenities(ctx context.Context, hotelId int) ([]models.Amenity, error) {
+	const op = "storage.memory.ListHotelAmenities"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.hotels[hotelId]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrHotelNotFound)
+	}
+
+	return m.offeredAmenities(m.hotelAmenities[hotelId]), nil
+}
+
+func (m *Memory) SetHotelAmenities(ctx context.Context, hotelId int, codes []string) ([]models.Amenity, error) {
+	const op = "storage.memory.SetHotelAmenities"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.hotels[hotelId]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrHotelNotFound)
+	}
+
+	offered, err := m.amenitySet(codes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: exec failed: %w", op, err)
+	}
+	m.hotelAmenities[hotelId] = offered
+
+	return m.offeredAmenities(offered), nil
+}
+
+func (m *Memory) ListRoomTypeAmenities(ctx context.Context, roomTypeId int) ([]models.Amenity, error) {
+	const op = "storage.memory.ListRoomTypeAmenities"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.roomTypes[roomTypeId]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	return m.offeredAmenities(m.roomTypeAmenities[roomTypeId]), nil
+}
+
+func (m *Memory) SetRoomTypeAmenities(ctx context.Context, roomTypeId int, codes []string) ([]models.Amenity, error) {
+	const op = "storage.memory.SetRoomTypeAmenities"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.roomTypes[roomTypeId]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	offered, err := m.amenitySet(codes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: exec failed: %w", op, err)
+	}
+	m.roomTypeAmenities[roomTypeId] = offered
+
+	return m.offeredAmenities(offered), nil
+}
+
+// amenitySet mirrors the amenity_code foreign keys of the link tables.
+func (m *Memory) amenitySet(codes []string) (map[string]bool, error) {
+	offered := make(map[string]bool)
+	for _, code := range storage.AmenityCodes(codes) {
+		if _, ok := m.amenities[code]; !ok {
+			return nil, storage.ErrAmenityNotFound
+		}
+		offered[code] = true
+	}
+	return offered, nil
+}
+
+// offersAmenities reports whether the hotel and its room type together offer
+// every code, like the condition of the postgres filters. roomTypeId 0 checks
+// the hotel alone.
+func (m *Memory) offersAmenities(hotelId, roomTypeId int, codes []string) bool {
+	for _, code := range storage.AmenityCodes(codes) {
+		if !m.hotelAmenities[hotelId][code] && !m.roomTypeAmenities[roomTypeId][code] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Memory) offeredAmenities(offered map[string]bool) []models.Amenity {
+	return m.sortedAmenities(func(a models.Amenity) bool { return offered[a.Code] })
+}
+
+// sortedAmenities returns the catalog entries kept by keep ordered by
+// category and code, like the ORDER BY of the postgres statements.
+func (m *Memory) sortedAmenities(keep func(models.Amenity) bool) []models.Amenity {
+	amenities := []models.Amenity{}
+	for _, a := range m.amenities {
+		if keep(a) {
+			a.Labels = cloneLabels(a.Labels)
+			amenities = append(amenities, a)
+		}
+	}
+
+	slices.SortFunc(amenities, func(a, b models.Amenity) int {
+		return cmp.Or(cmp.Compare(a.Category, b.Category), cmp.Compare(a.Code, b.Code))
+	})
+
+	return amenities
+}
+
+// cloneLabels copies labels so callers never share the stored map, and
+// turns nil into an empty map like the labels column default.
+func cloneLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return map[string]string{}
+	}
+	return maps.Clone(labels)
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"strings"
+)
+
+func (m *Memory) SearchAvailability(ctx context.Context, filter storage.AvailabilityFilter) ([]models.HotelAvailability, error) {
+	const op = "storage.memory.SearchAvailability"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hotels := []models.HotelAvailability{}
+	for _, h := range sortedById(m.hotels, nil) {
+		if h.Stars < filter.StarsMin {
+			continue
+		}
+		if filter.Country != "" && !strings.EqualFold(h.Country, filter.Country) {
+			continue
+		}
+		if filter.City != "" && !strings.EqualFold(h.City, filter.City) {
+			continue
+		}
+
+		var roomTypes []models.RoomTypeAvailability
+		for _, rt := range sortedById(m.roomTypes, nil) {
+			if rt.HotelId != h.Id || rt.MaxGuests() < filter.Guests || !m.offersAmenities(h.Id, rt.Id, filter.Amenities) {
+				continue
+			}
+			available := m.roomTypeAvailable(rt.Id, filter.CheckIn, filter.CheckOut)
+			if available <= 0 {
+				continue
+			}
+			if storage.CheckStay(m.stayRestrictions(rt.Id, filter.CheckIn, filter.CheckOut), filter.CheckIn, filter.CheckOut) != nil {
+				continue
+			}
+			rta := models.RoomTypeAvailability{RoomType: rt, Available: available}
+			storage.QuoteAvailability(&rta, m.roomTypeRatePlans(rt.Id), filter)
+			roomTypes = append(roomTypes, rta)
+		}
+
+		if len(roomTypes) > 0 {
+			hotels = append(hotels, models.HotelAvailability{Hotel: h, RoomTypes: roomTypes})
+		}
+	}
+
+	return hotels, nil
+}
@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (m *Memory) CreateHotel(ctx context.Context, country string, city string, hotelName string, stars int) (models.Hotel, error) {
+	const op = "storage.memory.CreateHotel"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkHotel(0, hotelName, stars); err != nil {
+		return models.Hotel{}, fmt.Errorf("%s: exec failed: %w", op, err)
+	}
+
+	m.lastHotelId++
+	hotel := models.Hotel{
+		Id:        m.lastHotelId,
+		Country:   country,
+		City:      city,
+		HotelName: hotelName,
+		Stars:     stars,
+	}
+	m.hotels[hotel.Id] = hotel
+
+	return hotel, nil
+}
+
+func (m *Memory) ListHotels(ctx context.Context, filter storage.HotelFilter) (models.Page[models.Hotel], error) {
+	const op = "storage.memory.ListHotels"
+
+	k, err := storage.NewKeyset(filter.PageRequest, storage.HotelSortFields, "id")
+	if err != nil {
+		return models.Page[models.Hotel]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return page(m.hotels, func(h models.Hotel) bool {
+		switch {
+		case filter.Country != "" && !strings.EqualFold(h.Country, filter.Country),
+			filter.City != "" && !strings.EqualFold(h.City, filter.City),
+			filter.NamePrefix != "" && !hasPrefixFold(h.HotelName, filter.NamePrefix),
+			filter.StarsMin != 0 && h.Stars < filter.StarsMin,
+			filter.StarsMax != 0 && h.Stars > filter.StarsMax,
+			!m.offersAmenities(h.Id, 0, filter.Amenities):
+			return false
+		}
+		return true
+	}, k), nil
+}
+
+func (m *Memory) GetHotel(ctx context.Context, id int) (models.Hotel, error) {
+	const op = "storage.memory.GetHotel"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hotel, ok := m.hotels[id]
+	if !ok {
+		return models.Hotel{}, fmt.Errorf("%s: %w", op, storage.ErrHotelNotFound)
+	}
+
+	return hotel, nil
+}
+
+func (m *Memory) UpdateHotel(ctx context.Context, id int, country string, city string, hotelName string, stars int) (models.Hotel, error) {
+	const op = "storage.memory.UpdateHotel"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.hotels[id]; !ok {
+		return models.Hotel{}, fmt.Errorf("%s: %w", op, storage.ErrHotelNotFound)
+	}
+
+	if err := m.checkHotel(id, hotelName, stars); err != nil {
+		return models.Hotel{}, fmt.Errorf("%s: update failed: %w", op, err)
+	}
+
+	hotel := models.Hotel{
+		Id:        id,
+		Country:   country,
+		City:      city,
+		HotelName: hotelName,
+		Stars:     stars,
+	}
+	m.hotels[id] = hotel
+
+	return hotel, nil
+}
+
+func (m *Memory) DeleteHotel(ctx context.Context, id int) error {
+	const op = "storage.memory.DeleteHotel"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.hotels[id]; !ok {
+		return fmt.Errorf("%s: %w", op, storage.ErrHotelNotFound)
+	}
+
+	// Every room has a room type of its hotel, so checking the types covers
+	// both.
+	for _, rt := range m.roomTypes {
+		if rt.HotelId == id {
+			return fmt.Errorf("%s: %w", op, storage.ErrHotelHasRooms)
+		}
+	}
+
+	delete(m.hotels, id)
+	delete(m.hotelAmenities, id)
+
+	return nil
+}
+
+// checkHotel mirrors the stars CHECK and the hotel_name UNIQUE constraint.
+func (m *Memory) checkHotel(id int, hotelName string, stars int) error {
+	if stars < 1 || stars > 5 {
+		return storage.ErrHotelInvalid
+	}
+
+	for _, h := range m.hotels {
+		if h.Id != id && h.HotelName == hotelName {
+			return storage.ErrHotelExists
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"fmt"
+)
+
+func (m *Memory) CreateHotelRoom(ctx context.Context, hotelId int, roomTypeId int, roomNumber int, floor int) (models.HotelRoom, error) {
+	const op = "storage.memory.CreateHotelRoom"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkHotelRoom(0, hotelId, roomTypeId, roomNumber); err != nil {
+		return models.HotelRoom{}, fmt.Errorf("%s: exec failed: %w", op, err)
+	}
+
+	m.lastHotelRoomId++
+	hr := models.HotelRoom{
+		Id:         m.lastHotelRoomId,
+		HotelId:    hotelId,
+		RoomTypeId: roomTypeId,
+		RoomNumber: roomNumber,
+		Floor:      floor,
+	}
+	m.hotelRooms[hr.Id] = hr
+
+	return hr, nil
+}
+
+func (m *Memory) ListHotelRooms(ctx context.Context, filter storage.HotelRoomFilter) (models.Page[models.HotelRoom], error) {
+	const op = "storage.memory.ListHotelRooms"
+
+	k, err := storage.NewKeyset(filter.PageRequest, storage.HotelRoomSortFields, "id")
+	if err != nil {
+		return models.Page[models.HotelRoom]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return page(m.hotelRooms, func(hr models.HotelRoom) bool {
+		return (filter.HotelId == 0 || hr.HotelId == filter.HotelId) &&
+			(filter.RoomTypeId == 0 || hr.RoomTypeId == filter.RoomTypeId) &&
+			(filter.GuestsMin == 0 || m.roomTypes[hr.RoomTypeId].MaxGuests() >= filter.GuestsMin)
+	}, k), nil
+}
+
+func (m *Memory) GetHotelRoom(ctx context.Context, id int) (models.HotelRoom, error) {
+	const op = "storage.memory.GetHotelRoom"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hr, ok := m.hotelRooms[id]
+	if !ok {
+		return models.HotelRoom{}, fmt.Errorf("%s: %w", op, storage.ErrHotelRoomNotFound)
+	}
+
+	return hr, nil
+}
+
+func (m *Memory) UpdateHotelRoom(ctx context.Context, id int, hotelId int, roomTypeId int, roomNumber int, floor int) (models.HotelRoom, error) {
+	const op = "storage.memory.UpdateHotelRoom"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.hotelRooms[id]
+	if !ok {
+		return models.HotelRoom{}, fmt.Errorf("%s: %w", op, storage.ErrHotelRoomNotFound)
+	}
+
+	if err := m.checkHotelRoom(id, hotelId, roomTypeId, roomNumber); err != nil {
+		return models.HotelRoom{}, fmt.Errorf("%s: update failed: %w", op, err)
+	}
+
+	// Mirrors reservations_hotel_room_fkey, which has no ON UPDATE action: a
+	// room assigned to a reservation keeps its type.
+	if roomTypeId != old.RoomTypeId {
+		for _, res := range m.reservations {
+			if res.HotelRoomId == id {
+				return models.HotelRoom{}, fmt.Errorf("%s: update failed: %w", op, storage.ErrHotelRoomHasReservations)
+			}
+		}
+	}
+
+	hr := models.HotelRoom{
+		Id:         id,
+		HotelId:    hotelId,
+		RoomTypeId: roomTypeId,
+		RoomNumber: roomNumber,
+		Floor:      floor,
+	}
+	m.hotelRooms[id] = hr
+
+	// Mirrors ON UPDATE CASCADE of the visitors' composite room key.
+	for visId, vis := range m.visitors {
+		if vis.HotelRoom == id {
+			vis.HotelId = hotelId
+			m.visitors[visId] = vis
+		}
+	}
+
+	return hr, nil
+}
+
+func (m *Memory) DeleteHotelRoom(ctx context.Context, id int) error {
+	const op = "storage.memory.DeleteHotelRoom"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.hotelRooms[id]; !ok {
+		return fmt.Errorf("%s: %w", op, storage.ErrHotelRoomNotFound)
+	}
+
+	for _, vis := range m.visitors {
+		if vis.HotelRoom == id {
+			return fmt.Errorf("%s: %w", op, storage.ErrHotelRoomHasVisitors)
+		}
+	}
+
+	for _, res := range m.reservations {
+		if res.HotelRoomId == id {
+			return fmt.Errorf("%s: %w", op, storage.ErrHotelRoomHasReservations)
+		}
+	}
+
+	delete(m.hotelRooms, id)
+
+	return nil
+}
+
+// checkHotelRoom mirrors the room_number CHECK, the hotel_id and composite
+// room type foreign keys and the UNIQUE (hotel_id, room_number) constraint.
+// id is the room being updated, or 0 on create.
+func (m *Memory) checkHotelRoom(id int, hotelId int, roomTypeId int, roomNumber int) error {
+	if roomNumber <= 0 {
+		return storage.ErrHotelRoomInvalid
+	}
+
+	if _, ok := m.hotels[hotelId]; !ok {
+		return storage.ErrHotelNotFound
+	}
+
+	if rt, ok := m.roomTypes[roomTypeId]; !ok || rt.HotelId != hotelId {
+		return storage.ErrRoomTypeNotFound
+	}
+
+	for _, hr := range m.hotelRooms {
+		if hr.Id != id && hr.HotelId == hotelId && hr.RoomNumber == roomNumber {
+			return storage.ErrHotelRoomExists
+		}
+	}
+
+	return nil
+}
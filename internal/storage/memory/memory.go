@@ -0,0 +1,134 @@
+// Package memory is an in-process implementation of storage.Repository. It
+// enforces the same constraints as the Postgres schema and is meant for tests.
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Memory struct {
+	mu sync.RWMutex
+
+	hotels       map[int]models.Hotel
+	roomTypes    map[int]models.RoomType
+	ratePlans    map[int]models.RatePlan
+	hotelRooms   map[int]models.HotelRoom
+	visitors     map[int]models.Visitor
+	reservations map[int]models.Reservation
+
+	// restrictions holds the stay restrictions of room types by type and day.
+	restrictions map[restrictionKey]models.StayRestriction
+
+	// amenities is the catalog by code; hotelAmenities and roomTypeAmenities
+	// hold the codes each hotel and room type offers.
+	amenities         map[string]models.Amenity
+	hotelAmenities    map[int]map[string]bool
+	roomTypeAmenities map[int]map[string]bool
+
+	lastHotelId       int
+	lastRoomTypeId    int
+	lastRatePlanId    int
+	lastHotelRoomId   int
+	lastVisitorId     int
+	lastReservationId int
+}
+
+var _ storage.Repository = (*Memory)(nil)
+
+func New() *Memory {
+	return &Memory{
+		hotels:       make(map[int]models.Hotel),
+		roomTypes:    make(map[int]models.RoomType),
+		ratePlans:    make(map[int]models.RatePlan),
+		hotelRooms:   make(map[int]models.HotelRoom),
+		visitors:     make(map[int]models.Visitor),
+		reservations: make(map[int]models.Reservation),
+
+		restrictions: make(map[restrictionKey]models.StayRestriction),
+
+		amenities:         make(map[string]models.Amenity),
+		hotelAmenities:    make(map[int]map[string]bool),
+		roomTypeAmenities: make(map[int]map[string]bool),
+	}
+}
+
+// sortedById returns the values of m ordered by id, like the ORDER BY id of
+// the Postgres list statements.
+func sortedById[T any](m map[int]T, keep func(T) bool) []T {
+	ids := make([]int, 0, len(m))
+	for id, v := range m {
+		if keep == nil || keep(v) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	values := make([]T, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, m[id])
+	}
+	return values
+}
+
+// page returns one keyset page of the values of m kept by keep, mirroring the
+// ORDER BY, keyset condition and LIMIT of the postgres list queries.
+func page[T any](m map[int]T, keep func(T) bool, k storage.Keyset[T]) models.Page[T] {
+	compare := func(a, b T) int {
+		c := compareValues(k.Field.Value(a), k.Field.Value(b))
+		if c == 0 {
+			c = cmp.Compare(k.IdField.Value(a).(int), k.IdField.Value(b).(int))
+		}
+		if k.Desc {
+			c = -c
+		}
+		return c
+	}
+
+	rows := sortedById(m, keep)
+	slices.SortStableFunc(rows, compare)
+
+	if k.HasAfter {
+		rows = slices.DeleteFunc(rows, func(v T) bool {
+			c := compareValues(k.Field.Value(v), k.After)
+			if c == 0 {
+				c = cmp.Compare(k.IdField.Value(v).(int), k.AfterId)
+			}
+			if k.Desc {
+				c = -c
+			}
+			return c <= 0
+		})
+	}
+
+	if len(rows) > k.Limit+1 {
+		rows = rows[:k.Limit+1]
+	}
+
+	return k.Page(rows)
+}
+
+// compareValues orders the values SortField.Value returns.
+func compareValues(a, b any) int {
+	switch a := a.(type) {
+	case int:
+		return cmp.Compare(a, b.(int))
+	case string:
+		return cmp.Compare(a, b.(string))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	panic(fmt.Sprintf("memory: cannot compare %T", a))
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring case, like
+// lower(s) LIKE lower(prefix) || '%'.
+func hasPrefixFold(s, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+}
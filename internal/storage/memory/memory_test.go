@@ -0,0 +1,14 @@
+package memory_test
+
+import (
+	"bookings/internal/storage"
+	"bookings/internal/storage/memory"
+	"bookings/internal/storage/storagetest"
+	"testing"
+)
+
+func TestRepositoryContract(t *testing.T) {
+	storagetest.RunRepositoryContract(t, func(t *testing.T) storage.Repository {
+		return memory.New()
+	})
+}
@@ -0,0 +1,191 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// currencyCode mirrors rate_plans_currency_check.
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func (m *Memory) CreateRatePlan(ctx context.Context, rp models.RatePlan) (models.RatePlan, error) {
+	const op = "storage.memory.CreateRatePlan"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkRatePlan(0, rp); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: exec failed: %w", op, err)
+	}
+
+	m.lastRatePlanId++
+	rp.Id = m.lastRatePlanId
+	m.ratePlans[rp.Id] = cloneRatePlan(rp)
+
+	return cloneRatePlan(rp), nil
+}
+
+func (m *Memory) ListRatePlans(ctx context.Context, roomTypeId int) ([]models.RatePlan, error) {
+	const op = "storage.memory.ListRatePlans"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.roomTypes[roomTypeId]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	return m.roomTypeRatePlans(roomTypeId), nil
+}
+
+func (m *Memory) GetRatePlan(ctx context.Context, id int) (models.RatePlan, error) {
+	const op = "storage.memory.GetRatePlan"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rp, ok := m.ratePlans[id]
+	if !ok {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, storage.ErrRatePlanNotFound)
+	}
+
+	return cloneRatePlan(rp), nil
+}
+
+func (m *Memory) UpdateRatePlan(ctx context.Context, id int, rp models.RatePlan) (models.RatePlan, error) {
+	const op = "storage.memory.UpdateRatePlan"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.ratePlans[id]
+	if !ok {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, storage.ErrRatePlanNotFound)
+	}
+
+	// A plan never moves to another room type.
+	rp.Id, rp.RoomTypeId = id, old.RoomTypeId
+	if err := m.checkRatePlan(id, rp); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: update failed: %w", op, err)
+	}
+	m.ratePlans[id] = cloneRatePlan(rp)
+
+	return cloneRatePlan(rp), nil
+}
+
+func (m *Memory) DeleteRatePlan(ctx context.Context, id int) error {
+	const op = "storage.memory.DeleteRatePlan"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.ratePlans[id]; !ok {
+		return fmt.Errorf("%s: %w", op, storage.ErrRatePlanNotFound)
+	}
+
+	for _, res := range m.reservations {
+		if res.RatePlanId == id {
+			return fmt.Errorf("%s: %w", op, storage.ErrRatePlanHasReservations)
+		}
+	}
+
+	delete(m.ratePlans, id)
+
+	return nil
+}
+
+func (m *Memory) QuoteStay(ctx context.Context, roomTypeId int, ratePlanId int, checkIn models.Date, checkOut models.Date, guests int) (models.Quote, error) {
+	const op = "storage.memory.QuoteStay"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if guests <= 0 || !checkOut.After(checkIn.Time) || storage.StayTooLong(checkIn, checkOut) {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, storage.ErrReservationInvalid)
+	}
+
+	rt, ok := m.roomTypes[roomTypeId]
+	if !ok {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	if guests > rt.MaxGuests() {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, storage.ErrReservationTooManyGuests)
+	}
+
+	quote, err := storage.PriceStay(m.roomTypeRatePlans(roomTypeId), rt, ratePlanId, checkIn, checkOut, guests)
+	if err != nil {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return quote, nil
+}
+
+// roomTypeRatePlans returns the plans of a room type ordered by id.
+func (m *Memory) roomTypeRatePlans(roomTypeId int) []models.RatePlan {
+	plans := sortedById(m.ratePlans, func(rp models.RatePlan) bool { return rp.RoomTypeId == roomTypeId })
+	for i := range plans {
+		plans[i] = cloneRatePlan(plans[i])
+	}
+	return plans
+}
+
+// checkRatePlan mirrors the CHECKs, keys and foreign keys of rate_plans,
+// rate_plan_seasons and rate_plan_discounts. id is the plan being updated, or
+// 0 on create.
+func (m *Memory) checkRatePlan(id int, rp models.RatePlan) error {
+	if !currencyCode.MatchString(rp.Currency) || rp.BasePrice <= 0 || rp.WeekendPrice < 0 || rp.ExtraGuestPrice < 0 {
+		return storage.ErrRatePlanInvalid
+	}
+	if err := storage.CheckRatePlan(rp); err != nil {
+		return err
+	}
+
+	for _, other := range m.ratePlans {
+		if other.Id != id && other.RoomTypeId == rp.RoomTypeId && other.Name == rp.Name {
+			return storage.ErrRatePlanExists
+		}
+	}
+
+	if _, ok := m.roomTypes[rp.RoomTypeId]; !ok {
+		return storage.ErrRoomTypeNotFound
+	}
+
+	for i, s := range rp.Seasons {
+		if s.From.IsZero() || s.To.IsZero() || s.To.Before(s.From.Time) || s.Price <= 0 || s.WeekendPrice < 0 {
+			return storage.ErrRatePlanSeasonInvalid
+		}
+		for _, other := range rp.Seasons[:i] {
+			if !s.From.After(other.To.Time) && !other.From.After(s.To.Time) {
+				return storage.ErrRatePlanSeasonInvalid
+			}
+		}
+	}
+
+	for i, d := range rp.StayDiscounts {
+		if d.MinNights < 2 || d.Percent < 1 || d.Percent > 100 {
+			return storage.ErrRatePlanDiscountInvalid
+		}
+		if slices.ContainsFunc(rp.StayDiscounts[:i], func(other models.StayDiscount) bool { return other.MinNights == d.MinNights }) {
+			return storage.ErrRatePlanDiscountInvalid
+		}
+	}
+
+	return nil
+}
+
+// cloneRatePlan copies the seasons and stay discounts of rp, ordered like the
+// postgres statements return them, so callers never share the stored slices.
+func cloneRatePlan(rp models.RatePlan) models.RatePlan {
+	rp.Seasons = append([]models.Season{}, rp.Seasons...)
+	slices.SortFunc(rp.Seasons, func(a, b models.Season) int { return a.From.Compare(b.From.Time) })
+
+	rp.StayDiscounts = append([]models.StayDiscount{}, rp.StayDiscounts...)
+	slices.SortFunc(rp.StayDiscounts, func(a, b models.StayDiscount) int { return a.MinNights - b.MinNights })
+
+	return rp
+}
@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+func (m *Memory) CreateReservation(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int) (models.Reservation, error) {
+	const op = "storage.memory.CreateReservation"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res, err := m.reserve(roomTypeId, ratePlanId, visitorId, checkIn, checkOut, guests, 0)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func (m *Memory) HoldReservation(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int, holdFor time.Duration) (models.Reservation, error) {
+	const op = "storage.memory.HoldReservation"
+
+	if holdFor <= 0 {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrHoldInvalid)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res, err := m.reserve(roomTypeId, ratePlanId, visitorId, checkIn, checkOut, guests, holdFor)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// reserve mirrors the postgres reserve: it books a reservation, or holds it
+// for holdFor when that is positive.
+func (m *Memory) reserve(roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int, holdFor time.Duration) (models.Reservation, error) {
+	if guests <= 0 || !checkOut.After(checkIn.Time) || storage.StayTooLong(checkIn, checkOut) {
+		return models.Reservation{}, storage.ErrReservationInvalid
+	}
+
+	rt, ok := m.roomTypes[roomTypeId]
+	if !ok {
+		return models.Reservation{}, storage.ErrRoomTypeNotFound
+	}
+
+	if guests > rt.MaxGuests() {
+		return models.Reservation{}, storage.ErrReservationTooManyGuests
+	}
+
+	if err := storage.CheckStay(m.stayRestrictions(roomTypeId, checkIn, checkOut), checkIn, checkOut); err != nil {
+		return models.Reservation{}, err
+	}
+
+	if m.roomTypeAvailable(roomTypeId, checkIn, checkOut) <= 0 {
+		return models.Reservation{}, storage.ErrRoomTypeSoldOut
+	}
+
+	var quote models.Quote
+	if plans := m.roomTypeRatePlans(roomTypeId); ratePlanId != 0 || len(plans) > 0 {
+		var err error
+		quote, err = storage.PriceStay(plans, rt, ratePlanId, checkIn, checkOut, guests)
+		if err != nil {
+			return models.Reservation{}, err
+		}
+	}
+
+	if _, ok := m.visitors[visitorId]; !ok {
+		return models.Reservation{}, fmt.Errorf("exec failed: %w", storage.ErrVisitorNotFound)
+	}
+
+	now := time.Now().UTC()
+
+	m.lastReservationId++
+	res := models.Reservation{
+		Id:         m.lastReservationId,
+		RoomTypeId: roomTypeId,
+		VisitorId:  visitorId,
+		CheckIn:    checkIn,
+		CheckOut:   checkOut,
+		Guests:     guests,
+		Status:     models.ReservationPending,
+		CreatedAt:  now,
+		RatePlanId: quote.RatePlanId,
+		Currency:   quote.Currency,
+		Total:      quote.Total,
+	}
+	if holdFor > 0 {
+		expiresAt := now.Add(holdFor)
+		res.Status, res.ExpiresAt = models.ReservationHeld, &expiresAt
+	}
+	m.reservations[res.Id] = res
+
+	return res, nil
+}
+
+func (m *Memory) ListReservations(ctx context.Context, filter storage.ReservationFilter) (models.Page[models.Reservation], error) {
+	const op = "storage.memory.ListReservations"
+
+	k, err := storage.NewKeyset(filter.PageRequest, storage.ReservationSortFields, "check_in")
+	if err != nil {
+		return models.Page[models.Reservation]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return page(m.reservations, func(res models.Reservation) bool {
+		switch {
+		case filter.HotelId != 0 && m.roomTypes[res.RoomTypeId].HotelId != filter.HotelId,
+			filter.RoomTypeId != 0 && res.RoomTypeId != filter.RoomTypeId,
+			filter.HotelRoomId != 0 && res.HotelRoomId != filter.HotelRoomId,
+			filter.VisitorId != 0 && res.VisitorId != filter.VisitorId,
+			filter.Status != "" && res.Status != filter.Status,
+			!filter.CheckInFrom.IsZero() && res.CheckIn.Before(filter.CheckInFrom.Time),
+			!filter.CheckInTo.IsZero() && res.CheckIn.After(filter.CheckInTo.Time):
+			return false
+		}
+		return true
+	}, k), nil
+}
+
+func (m *Memory) GetReservation(ctx context.Context, id int) (models.Reservation, error) {
+	const op = "storage.memory.GetReservation"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res, ok := m.reservations[id]
+	if !ok {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrReservationNotFound)
+	}
+
+	return res, nil
+}
+
+func (m *Memory) CancelReservation(ctx context.Context, id int) (models.Reservation, error) {
+	const op = "storage.memory.CancelReservation"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res, ok := m.reservations[id]
+	if !ok {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrReservationNotFound)
+	}
+
+	switch res.Status {
+	case models.ReservationHeld, models.ReservationPending, models.ReservationConfirmed:
+	default:
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrReservationNotCancellable)
+	}
+
+	res.Status = models.ReservationCancelled
+	m.reservations[id] = res
+
+	return res, nil
+}
+
+func (m *Memory) ConfirmReservation(ctx context.Context, id int) (models.Reservation, error) {
+	const op = "storage.memory.ConfirmReservation"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res, ok := m.reservations[id]
+	if !ok {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrReservationNotFound)
+	}
+
+	switch {
+	case res.Status == models.ReservationHeld && res.ExpiresAt.After(time.Now()), res.Status == models.ReservationPending:
+	case res.Status == models.ReservationHeld, res.Status == models.ReservationExpired:
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrHoldExpired)
+	default:
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrReservationNotConfirmable)
+	}
+
+	res.Status, res.ExpiresAt = models.ReservationConfirmed, nil
+	m.reservations[id] = res
+
+	return res, nil
+}
+
+func (m *Memory) ExpireHolds(ctx context.Context, limit int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	var due []models.Reservation
+	for _, res := range m.reservations {
+		if res.Status == models.ReservationHeld && !res.ExpiresAt.After(now) {
+			due = append(due, res)
+		}
+	}
+	slices.SortFunc(due, func(a, b models.Reservation) int { return a.ExpiresAt.Compare(*b.ExpiresAt) })
+
+	expired := 0
+	for _, res := range due[:min(limit, len(due))] {
+		res.Status = models.ReservationExpired
+		m.reservations[res.Id] = res
+		expired++
+	}
+
+	return expired, nil
+}
+
+func (m *Memory) CheckInReservation(ctx context.Context, id int, hotelRoomId int) (models.Reservation, error) {
+	const op = "storage.memory.CheckInReservation"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res, ok := m.reservations[id]
+	if !ok {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrReservationNotFound)
+	}
+
+	if res.Status != models.ReservationPending && res.Status != models.ReservationConfirmed {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrReservationNotCheckable)
+	}
+
+	if hotelRoomId == 0 {
+		hotelRoomId = res.HotelRoomId
+	}
+	if hotelRoomId == 0 {
+		hotelRoomId = m.freeHotelRoom(res.RoomTypeId, res.CheckIn, res.CheckOut)
+		if hotelRoomId == 0 {
+			return models.Reservation{}, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeSoldOut)
+		}
+	}
+
+	hr, ok := m.hotelRooms[hotelRoomId]
+	switch {
+	case !ok:
+		return models.Reservation{}, fmt.Errorf("%s: exec failed: %w", op, storage.ErrHotelRoomNotFound)
+	case m.roomBooked(hotelRoomId, res.CheckIn, res.CheckOut, id):
+		return models.Reservation{}, fmt.Errorf("%s: exec failed: %w", op, storage.ErrRoomAlreadyBooked)
+	case hr.RoomTypeId != res.RoomTypeId:
+		return models.Reservation{}, fmt.Errorf("%s: exec failed: %w", op, storage.ErrRoomTypeMismatch)
+	}
+
+	res.HotelRoomId = hotelRoomId
+	res.Status = models.ReservationCheckedIn
+	m.reservations[id] = res
+
+	return res, nil
+}
+
+// roomBooked mirrors the reservations_no_overlap exclusion constraint for the
+// reservation exceptId being assigned the room.
+func (m *Memory) roomBooked(hotelRoomId int, checkIn models.Date, checkOut models.Date, exceptId int) bool {
+	for _, res := range m.reservations {
+		if res.Id == exceptId || res.HotelRoomId != hotelRoomId || res.Status == models.ReservationCancelled {
+			continue
+		}
+		if res.CheckIn.Before(checkOut.Time) && checkIn.Before(res.CheckOut.Time) {
+			return true
+		}
+	}
+	return false
+}
+
+// freeHotelRoom mirrors the free_hotel_room statement: the room of the type
+// with the lowest number that no reservation holds during the stay, or 0.
+func (m *Memory) freeHotelRoom(roomTypeId int, checkIn models.Date, checkOut models.Date) int {
+	free := 0
+	for _, hr := range m.hotelRooms {
+		if hr.RoomTypeId != roomTypeId || m.roomBooked(hr.Id, checkIn, checkOut, 0) {
+			continue
+		}
+		if free == 0 || hr.RoomNumber < m.hotelRooms[free].RoomNumber {
+			free = hr.Id
+		}
+	}
+	return free
+}
+
+// live mirrors the liveReservation SQL condition: res blocks its room type
+// at now.
+func live(res models.Reservation, now time.Time) bool {
+	if res.Status == models.ReservationCancelled || res.Status == models.ReservationExpired {
+		return false
+	}
+	return res.ExpiresAt == nil || res.ExpiresAt.After(now)
+}
+
+// roomTypeAvailable mirrors the SQL of the same name: the rooms of the type
+// minus the live reservations on its busiest night.
+func (m *Memory) roomTypeAvailable(roomTypeId int, checkIn models.Date, checkOut models.Date) int {
+	rooms := 0
+	for _, hr := range m.hotelRooms {
+		if hr.RoomTypeId == roomTypeId {
+			rooms++
+		}
+	}
+
+	now := time.Now()
+
+	busiest := 0
+	for night := checkIn.Time; night.Before(checkOut.Time); night = night.AddDate(0, 0, 1) {
+		booked := 0
+		for _, res := range m.reservations {
+			if res.RoomTypeId == roomTypeId && live(res, now) &&
+				!res.CheckIn.After(night) && res.CheckOut.After(night) {
+				booked++
+			}
+		}
+		busiest = max(busiest, booked)
+	}
+
+	return rooms - busiest
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"fmt"
+)
+
+// restrictionKey identifies a row of stay_restrictions.
+type restrictionKey struct {
+	roomTypeId int
+	day        string
+}
+
+func (m *Memory) ListRestrictions(ctx context.Context, roomTypeId int, from models.Date, to models.Date) ([]models.StayRestriction, error) {
+	const op = "storage.memory.ListRestrictions"
+
+	if err := storage.CheckRestrictionRange(from, to); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.roomTypes[roomTypeId]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	return m.stayRestrictions(roomTypeId, from, to), nil
+}
+
+func (m *Memory) UpdateRestrictions(ctx context.Context, roomTypeId int, u models.RestrictionUpdate) ([]models.StayRestriction, error) {
+	const op = "storage.memory.UpdateRestrictions"
+
+	if err := storage.CheckRestrictionRange(u.From, u.To); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.roomTypes[roomTypeId]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	// Check every date before changing any, like the single statement.
+	updated := make(map[restrictionKey]models.StayRestriction)
+	for _, d := range u.Dates() {
+		key := restrictionKey{roomTypeId, d.String()}
+
+		old, ok := m.restrictions[key]
+		if !ok {
+			old = models.StayRestriction{RoomTypeId: roomTypeId, Date: d}
+		}
+
+		r := u.Apply(old)
+		if r.MinStay < 0 || r.MaxStay < 0 || (r.MaxStay != 0 && r.MaxStay < r.MinStay) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrRestrictionInvalid)
+		}
+		updated[key] = r
+	}
+
+	for key, r := range updated {
+		if r.Restricts() {
+			m.restrictions[key] = r
+		} else {
+			delete(m.restrictions, key)
+		}
+	}
+
+	return m.stayRestrictions(roomTypeId, u.From, u.To), nil
+}
+
+// stayRestrictions returns the restrictions of a room type from from to to,
+// both included, ordered by date.
+func (m *Memory) stayRestrictions(roomTypeId int, from, to models.Date) []models.StayRestriction {
+	restrictions := []models.StayRestriction{}
+	for d := from; !d.After(to.Time); d = models.NewDate(d.AddDate(0, 0, 1)) {
+		if r, ok := m.restrictions[restrictionKey{roomTypeId, d.String()}]; ok {
+			restrictions = append(restrictions, r)
+		}
+	}
+	return restrictions
+}
+
+// deleteRestrictions deletes the restrictions of a room type, like the ON
+// DELETE CASCADE of stay_restrictions_room_type_id_fkey.
+func (m *Memory) deleteRestrictions(roomTypeId int) {
+	for key := range m.restrictions {
+		if key.roomTypeId == roomTypeId {
+			delete(m.restrictions, key)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"fmt"
+)
+
+func (m *Memory) CreateRoomType(ctx context.Context, rt models.RoomType) (models.RoomType, error) {
+	const op = "storage.memory.CreateRoomType"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkRoomType(0, rt); err != nil {
+		return models.RoomType{}, fmt.Errorf("%s: exec failed: %w", op, err)
+	}
+
+	m.lastRoomTypeId++
+	rt.Id = m.lastRoomTypeId
+	m.roomTypes[rt.Id] = rt
+
+	return rt, nil
+}
+
+func (m *Memory) ListRoomTypes(ctx context.Context, filter storage.RoomTypeFilter) (models.Page[models.RoomType], error) {
+	const op = "storage.memory.ListRoomTypes"
+
+	k, err := storage.NewKeyset(filter.PageRequest, storage.RoomTypeSortFields, "id")
+	if err != nil {
+		return models.Page[models.RoomType]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return page(m.roomTypes, func(rt models.RoomType) bool {
+		return (filter.HotelId == 0 || rt.HotelId == filter.HotelId) &&
+			(filter.GuestsMin == 0 || rt.MaxGuests() >= filter.GuestsMin) &&
+			m.offersAmenities(rt.HotelId, rt.Id, filter.Amenities)
+	}, k), nil
+}
+
+func (m *Memory) GetRoomType(ctx context.Context, id int) (models.RoomType, error) {
+	const op = "storage.memory.GetRoomType"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rt, ok := m.roomTypes[id]
+	if !ok {
+		return models.RoomType{}, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	return rt, nil
+}
+
+func (m *Memory) UpdateRoomType(ctx context.Context, id int, rt models.RoomType) (models.RoomType, error) {
+	const op = "storage.memory.UpdateRoomType"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.roomTypes[id]
+	if !ok {
+		return models.RoomType{}, fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	// A type never moves to another hotel.
+	rt.Id, rt.HotelId = id, old.HotelId
+	if err := m.checkRoomType(id, rt); err != nil {
+		return models.RoomType{}, fmt.Errorf("%s: update failed: %w", op, err)
+	}
+	m.roomTypes[id] = rt
+
+	return rt, nil
+}
+
+func (m *Memory) DeleteRoomType(ctx context.Context, id int) error {
+	const op = "storage.memory.DeleteRoomType"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.roomTypes[id]; !ok {
+		return fmt.Errorf("%s: %w", op, storage.ErrRoomTypeNotFound)
+	}
+
+	for _, res := range m.reservations {
+		if res.RoomTypeId == id {
+			return fmt.Errorf("%s: %w", op, storage.ErrRoomTypeHasReservations)
+		}
+	}
+
+	for _, hr := range m.hotelRooms {
+		if hr.RoomTypeId == id {
+			return fmt.Errorf("%s: %w", op, storage.ErrRoomTypeHasRooms)
+		}
+	}
+
+	delete(m.roomTypes, id)
+	delete(m.roomTypeAmenities, id)
+	m.deleteRatePlans(id)
+	m.deleteRestrictions(id)
+
+	return nil
+}
+
+// deleteRatePlans deletes the plans of a room type, like the ON DELETE
+// CASCADE of rate_plans_room_type_id_fkey.
+func (m *Memory) deleteRatePlans(roomTypeId int) {
+	for planId, rp := range m.ratePlans {
+		if rp.RoomTypeId == roomTypeId {
+			delete(m.ratePlans, planId)
+		}
+	}
+}
+
+// checkRoomType mirrors the room_types CHECKs, the hotel_id foreign key and
+// the UNIQUE (hotel_id, name) constraint. id is the type being updated, or 0
+// on create.
+func (m *Memory) checkRoomType(id int, rt models.RoomType) error {
+	if rt.MaxAdults <= 0 || rt.MaxChildren < 0 || rt.SizeSqm < 0 ||
+		rt.BaseOccupancy < 1 || rt.BaseOccupancy > rt.MaxGuests() {
+		return storage.ErrRoomTypeInvalid
+	}
+
+	if _, ok := m.hotels[rt.HotelId]; !ok {
+		return storage.ErrHotelNotFound
+	}
+
+	for _, other := range m.roomTypes {
+		if other.Id != id && other.HotelId == rt.HotelId && other.Name == rt.Name {
+			return storage.ErrRoomTypeExists
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"fmt"
+)
+
+func (m *Memory) CreateVisitor(ctx context.Context, hotelId int, hotelRoom int, firstName string, lastName string, age int) (models.Visitor, error) {
+	const op = "storage.memory.CreateVisitor"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkVisitor(hotelId, hotelRoom, age); err != nil {
+		return models.Visitor{}, fmt.Errorf("%s: exec failed: %w", op, err)
+	}
+
+	m.lastVisitorId++
+	vis := models.Visitor{
+		Id:        m.lastVisitorId,
+		HotelId:   hotelId,
+		HotelRoom: hotelRoom,
+		FirstName: firstName,
+		LastName:  lastName,
+		Age:       age,
+	}
+	m.visitors[vis.Id] = vis
+
+	return vis, nil
+}
+
+func (m *Memory) ListVisitors(ctx context.Context, filter storage.VisitorFilter) (models.Page[models.Visitor], error) {
+	const op = "storage.memory.ListVisitors"
+
+	k, err := storage.NewKeyset(filter.PageRequest, storage.VisitorSortFields, "id")
+	if err != nil {
+		return models.Page[models.Visitor]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return page(m.visitors, func(vis models.Visitor) bool {
+		return (filter.HotelId == 0 || vis.HotelId == filter.HotelId) &&
+			(filter.HotelRoomId == 0 || vis.HotelRoom == filter.HotelRoomId) &&
+			(filter.LastNamePrefix == "" || hasPrefixFold(vis.LastName, filter.LastNamePrefix))
+	}, k), nil
+}
+
+func (m *Memory) GetVisitor(ctx context.Context, id int) (models.Visitor, error) {
+	const op = "storage.memory.GetVisitor"
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	vis, ok := m.visitors[id]
+	if !ok {
+		return models.Visitor{}, fmt.Errorf("%s: %w", op, storage.ErrVisitorNotFound)
+	}
+
+	return vis, nil
+}
+
+func (m *Memory) UpdateVisitor(ctx context.Context, id int, hotelId int, hotelRoom int, firstName string, lastName string, age int) (models.Visitor, error) {
+	const op = "storage.memory.UpdateVisitor"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.visitors[id]; !ok {
+		return models.Visitor{}, fmt.Errorf("%s: %w", op, storage.ErrVisitorNotFound)
+	}
+
+	if err := m.checkVisitor(hotelId, hotelRoom, age); err != nil {
+		return models.Visitor{}, fmt.Errorf("%s: exec failed: %w", op, err)
+	}
+
+	vis := models.Visitor{
+		Id:        id,
+		HotelId:   hotelId,
+		HotelRoom: hotelRoom,
+		FirstName: firstName,
+		LastName:  lastName,
+		Age:       age,
+	}
+	m.visitors[id] = vis
+
+	return vis, nil
+}
+
+func (m *Memory) DeleteVisitor(ctx context.Context, id int) error {
+	const op = "storage.memory.DeleteVisitor"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.visitors[id]; !ok {
+		return fmt.Errorf("%s: %w", op, storage.ErrVisitorNotFound)
+	}
+
+	for _, res := range m.reservations {
+		if res.VisitorId == id {
+			return fmt.Errorf("%s: %w", op, storage.ErrVisitorHasReservations)
+		}
+	}
+
+	delete(m.visitors, id)
+
+	return nil
+}
+
+// checkVisitor mirrors the age CHECK and the composite (hotel_id,
+// hotel_room_id) foreign key.
+func (m *Memory) checkVisitor(hotelId int, hotelRoom int, age int) error {
+	if age < 18 || age > 100 {
+		return storage.ErrVisitorInvalidAge
+	}
+
+	hr, ok := m.hotelRooms[hotelRoom]
+	if !ok {
+		return storage.ErrHotelRoomNotFound
+	}
+	if hr.HotelId != hotelId {
+		return storage.ErrVisitorHotelMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"bookings/internal/logger"
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	operationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bookings_storage_operation_duration_seconds",
+		Help:    "Duration of storage operations, labelled by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"op"})
+
+	operationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bookings_storage_operation_errors_total",
+		Help: "Failed storage operations, labelled by operation and error category.",
+	}, []string{"op", "kind"})
+
+	reservationsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bookings_reservations_created_total",
+		Help: "Reservations created, directly or by confirming a hold.",
+	})
+
+	reservationsCancelled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bookings_reservations_cancelled_total",
+		Help: "Reservations cancelled.",
+	})
+
+	holdsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bookings_holds_created_total",
+		Help: "Reservations held during checkout.",
+	})
+
+	holdsExpired = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bookings_holds_expired_total",
+		Help: "Holds released because they expired.",
+	})
+)
+
+// observe records the duration and outcome of the storage operation op and
+// logs it at debug level with the request-scoped logger of ctx. It is
+// deferred at the top of every repository method with its named error result.
+func observe(ctx context.Context, op string, start time.Time, err *error) {
+	elapsed := time.Since(start)
+	operationDuration.WithLabelValues(op).Observe(elapsed.Seconds())
+
+	log := logger.FromContext(ctx, slog.Default())
+	if *err != nil {
+		kind := errorKind(*err)
+		operationErrors.WithLabelValues(op, kind).Inc()
+		log.DebugContext(ctx, "storage operation failed",
+			slog.String("op", op),
+			slog.Duration("duration", elapsed),
+			slog.String("kind", kind),
+			logger.Err(*err),
+		)
+		return
+	}
+	log.DebugContext(ctx, "storage operation", slog.String("op", op), slog.Duration("duration", elapsed))
+}
+
+// errorKind names the category of err for metric labels.
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrConflict):
+		return "conflict"
+	case errors.Is(err, ErrValidation):
+		return "validation"
+	case errors.Is(err, ErrUnavailable):
+		return "unavailable"
+	default:
+		return "internal"
+	}
+}
+
+var (
+	poolAcquiredConns = prometheus.NewDesc("bookings_db_pool_acquired_connections", "Connections currently in use.", nil, nil)
+	poolIdleConns     = prometheus.NewDesc("bookings_db_pool_idle_connections", "Idle connections in the pool.", nil, nil)
+	poolTotalConns    = prometheus.NewDesc("bookings_db_pool_total_connections", "Connections in the pool, including those being opened.", nil, nil)
+	poolMaxConns      = prometheus.NewDesc("bookings_db_pool_max_connections", "Maximum size of the pool.", nil, nil)
+	poolAcquires      = prometheus.NewDesc("bookings_db_pool_acquires_total", "Successful connection acquisitions.", nil, nil)
+	poolEmptyAcquires = prometheus.NewDesc("bookings_db_pool_empty_acquires_total", "Acquisitions that had to wait for a connection.", nil, nil)
+	poolCanceled      = prometheus.NewDesc("bookings_db_pool_canceled_acquires_total", "Acquisitions cancelled by their context.", nil, nil)
+	poolAcquireTime   = prometheus.NewDesc("bookings_db_pool_acquire_duration_seconds_total", "Total time spent acquiring connections.", nil, nil)
+
+	hotelOccupancy = prometheus.NewDesc("bookings_hotel_occupancy_ratio", "Share of a hotel's rooms with an active reservation today.", []string{"hotel_id"}, nil)
+)
+
+// occupancyTimeout bounds the occupancy query run on every scrape.
+const occupancyTimeout = 5 * time.Second
+
+// Collector exposes the connection pool statistics and the occupancy of
+// every hotel. Both are read at scrape time.
+func (pos *Postgres) Collector() prometheus.Collector {
+	return postgresCollector{pos: pos}
+}
+
+type postgresCollector struct {
+	pos *Postgres
+}
+
+func (c postgresCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		poolAcquiredConns, poolIdleConns, poolTotalConns, poolMaxConns,
+		poolAcquires, poolEmptyAcquires, poolCanceled, poolAcquireTime, hotelOccupancy,
+	} {
+		ch <- d
+	}
+}
+
+func (c postgresCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pos.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceled, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireTime, prometheus.CounterValue, s.AcquireDuration().Seconds())
+
+	ctx, cancel := context.WithTimeout(context.Background(), occupancyTimeout)
+	defer cancel()
+
+	occupancy, err := c.pos.HotelOccupancy(ctx, time.Now())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(hotelOccupancy, err)
+		return
+	}
+	for _, o := range occupancy {
+		ch <- prometheus.MustNewConstMetric(hotelOccupancy, prometheus.GaugeValue, o.Ratio(), strconv.Itoa(o.HotelId))
+	}
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"bookings/internal/config"
+	"bookings/internal/migrations"
+	"bookings/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserve(t *testing.T) {
+	const op = "storage.postgres.TestObserve"
+
+	ok := func() (err error) {
+		defer observe(context.Background(), op, time.Now(), &err)
+		return nil
+	}
+	failing := func(cause error) (err error) {
+		defer observe(context.Background(), op, time.Now(), &err)
+		return fmt.Errorf("%s: %w", op, cause)
+	}
+
+	_ = ok()
+	_ = failing(ErrHotelNotFound)
+	_ = failing(ErrHotelNotFound)
+	_ = failing(errors.New("boom"))
+
+	if got := testutil.ToFloat64(operationErrors.WithLabelValues(op, "not_found")); got != 2 {
+		t.Errorf("not_found errors = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(operationErrors.WithLabelValues(op, "internal")); got != 1 {
+		t.Errorf("internal errors = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(operationDuration, "bookings_storage_operation_duration_seconds"); got < 1 {
+		t.Errorf("no latency series recorded")
+	}
+}
+
+// TestReservationCounters runs against BOOKINGS_TEST_DATABASE_URL and
+// truncates all tables, so point it at a scratch database only.
+func TestReservationCounters(t *testing.T) {
+	url := os.Getenv("BOOKINGS_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("BOOKINGS_TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+
+	db, err := migrations.Open(url)
+	if err != nil {
+		t.Fatalf("open migrations db: %v", err)
+	}
+	err = migrations.Up(ctx, db)
+	db.Close()
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	pos, err := NewPostgresDb(&config.Config{
+		DatabaseUrl:             url,
+		DatabaseMaxConns:        4,
+		DatabaseMaxConnLifetime: time.Hour,
+		DatabaseMaxConnIdleTime: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("failed to init storage: %v", err)
+	}
+	defer pos.Close()
+
+	_, err = pos.pool.Exec(ctx, `TRUNCATE reservations, visitors, hotel_rooms, stay_restrictions, rate_plans, room_types, hotels, amenities RESTART IDENTITY CASCADE`)
+	if err != nil {
+		t.Fatalf("truncate failed: %v", err)
+	}
+
+	hotel, err := pos.CreateHotel(ctx, "Italy", "Rome", "Hassler", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomType, err := pos.CreateRoomType(ctx, models.RoomType{HotelId: hotel.Id, Name: "Double", MaxAdults: 2, BaseOccupancy: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	room, err := pos.CreateHotelRoom(ctx, hotel.Id, roomType.Id, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vis, err := pos.CreateVisitor(ctx, hotel.Id, room.Id, "Max", "Mustermann", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counters := map[string]prometheus.Counter{
+		"created":   reservationsCreated,
+		"cancelled": reservationsCancelled,
+		"held":      holdsCreated,
+	}
+	before := map[string]float64{}
+	for name, c := range counters {
+		before[name] = testutil.ToFloat64(c)
+	}
+	expect := func(step string, want map[string]float64) {
+		t.Helper()
+		for name, c := range counters {
+			if got := testutil.ToFloat64(c) - before[name]; got != want[name] {
+				t.Errorf("%s: %s went up by %v, want %v", step, name, got, want[name])
+			}
+		}
+	}
+
+	stay := func(day int) (models.Date, models.Date) {
+		checkIn := models.NewDate(time.Date(2030, time.July, day, 0, 0, 0, 0, time.UTC))
+		return checkIn, models.NewDate(checkIn.AddDate(0, 0, 1))
+	}
+
+	checkIn, checkOut := stay(1)
+	booked, err := pos.CreateReservation(ctx, roomType.Id, 0, vis.Id, checkIn, checkOut, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect("create", map[string]float64{"created": 1})
+
+	// Confirming a pending reservation does not count it again.
+	if _, err := pos.ConfirmReservation(ctx, booked.Id); err != nil {
+		t.Fatal(err)
+	}
+	expect("confirm pending", map[string]float64{"created": 1})
+
+	// A hold counts as created once it is confirmed, and only once.
+	checkIn, checkOut = stay(2)
+	hold, err := pos.HoldReservation(ctx, roomType.Id, 0, vis.Id, checkIn, checkOut, 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect("hold", map[string]float64{"created": 1, "held": 1})
+
+	if _, err := pos.ConfirmReservation(ctx, hold.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pos.ConfirmReservation(ctx, hold.Id); err == nil {
+		t.Fatal("confirming a hold twice succeeded")
+	}
+	expect("confirm hold", map[string]float64{"created": 2, "held": 1})
+
+	// A hold cancelled before confirmation is never counted as created.
+	checkIn, checkOut = stay(3)
+	dropped, err := pos.HoldReservation(ctx, roomType.Id, 0, vis.Id, checkIn, checkOut, 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pos.CancelReservation(ctx, dropped.Id); err != nil {
+		t.Fatal(err)
+	}
+	expect("cancel hold", map[string]float64{"created": 2, "cancelled": 1, "held": 2})
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+// HotelOccupancy returns, for every hotel, its rooms and how many live
+// reservations cover day. Reservations book a room type, so they
+// count against the hotel whether or not a room was assigned yet.
+func (pos *Postgres) HotelOccupancy(ctx context.Context, day time.Time) (_ []models.HotelOccupancy, err error) {
+	const op = "storage.postgres.HotelOccupancy"
+	defer observe(ctx, op, time.Now(), &err)
+
+	rows, err := pos.pool.Query(ctx, `SELECT h.id,
+		(SELECT count(*) FROM hotel_rooms hr WHERE hr.hotel_id = h.id),
+		(SELECT count(*) FROM reservations r
+		 JOIN room_types rt ON rt.id = r.room_type_id
+		 WHERE rt.hotel_id = h.id
+		   AND `+liveReservation("r")+`
+		   AND r.check_in <= $1::date AND r.check_out > $1::date)
+	FROM hotels h
+	ORDER BY h.id`, day.Format(time.DateOnly))
+	if err != nil {
+		return nil, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+	defer rows.Close()
+
+	occupancy := []models.HotelOccupancy{}
+	for rows.Next() {
+		var o models.HotelOccupancy
+		if err := rows.Scan(&o.HotelId, &o.Rooms, &o.Occupied); err != nil {
+			return nil, fmt.Errorf("%s: scan failed: %w", op, pgError(err))
+		}
+		occupancy = append(occupancy, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows failed: %w", op, pgError(err))
+	}
+
+	return occupancy, nil
+}
@@ -2,160 +2,498 @@ package storage
 
 import (
 	"bookings/internal/config"
-	_ "bookings/internal/migrations"
 	"context"
-	"database/sql"
 	"fmt"
-	"log/slog"
 	"time"
 
 	"github.com/jackc/pgx/v5"
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/pressly/goose"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
 )
 
 type Postgres struct {
-	conn *pgx.Conn
+	pool *pgxpool.Pool
 }
 
 func NewPostgresDb(conf *config.Config) (*Postgres, error) {
 	const op = "storage.postgres.NewPostgresDB"
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	conn, err := pgx.Connect(ctx, conf.DatabaseUrl)
+
+	poolConf, err := pgxpool.ParseConfig(conf.DatabaseUrl)
 	if err != nil {
-		return nil, fmt.Errorf("%s: connection failed: %w", op, err)
+		return nil, fmt.Errorf("%s: parse pool config failed: %w", op, err)
 	}
 
-	// make migration
+	poolConf.MaxConns = conf.DatabaseMaxConns
+	poolConf.MinConns = conf.DatabaseMinConns
+	poolConf.MaxConnLifetime = conf.DatabaseMaxConnLifetime
+	poolConf.MaxConnIdleTime = conf.DatabaseMaxConnIdleTime
+
+	// Prepared statements live on a single connection, so every connection the
+	// pool opens has to prepare them again.
+	poolConf.AfterConnect = prepareStatements
 
-	sqlDB, err := sql.Open("pgx", conf.DatabaseUrl)
+	// The global provider delegates to whatever tracing.Setup installs.
+	poolConf.ConnConfig.Tracer = newQueryTracer(otel.GetTracerProvider())
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConf)
 	if err != nil {
-		return nil, fmt.Errorf("%s: connection for migrations DB failed: %w", op, err)
+		return nil, fmt.Errorf("%s: connection failed: %w", op, err)
 	}
 
-	goose.SetDialect("postgres")
-
-	if conf.Reload {
-		slog.Info("Start reload db")
-		err = goose.DownTo(sqlDB, ".", 0)
-		if err != nil {
-			return nil, fmt.Errorf("%s: Reload failed: %w", op, err)
-		}
+	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("%s: ping failed: %w", op, err)
 	}
 
-	slog.Info("Migration started!")
-	err = goose.Up(sqlDB, "D:/Bookings-1/internal/migrations")
-	if err != nil {
-		return nil, fmt.Errorf("%s: Migration failed: %w", op, err)
+	return &Postgres{pool: pool}, nil
+}
+
+func (pos *Postgres) Close() {
+	pos.pool.Close()
+}
+
+// Ping checks that a pooled connection to the database works.
+func (pos *Postgres) Ping(ctx context.Context) error {
+	const op = "storage.postgres.Ping"
+
+	if err := pos.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, pgError(err))
 	}
 
-	// HOTELS TABLE
+	return nil
+}
 
-	// CreateHotel stmt
-	_, err = conn.Prepare(ctx, "create_hotel", `INSERT INTO hotels(country, city, hotel_name, stars) VALUES($1, $2, $3, $4)`)
+// MigrationVersion returns the schema version recorded by goose. Like goose,
+// it takes the most recently applied version that was not rolled back since.
+func (pos *Postgres) MigrationVersion(ctx context.Context) (int64, error) {
+	const op = "storage.postgres.MigrationVersion"
+
+	var version int64
+	err := pos.pool.QueryRow(ctx, `SELECT version_id FROM (
+		SELECT DISTINCT ON (version_id) id, version_id, is_applied
+		FROM goose_db_version
+		ORDER BY version_id, id DESC
+	) latest
+	WHERE is_applied
+	ORDER BY id DESC
+	LIMIT 1`).Scan(&version)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare create_hotel failed: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, pgError(err))
 	}
 
-	// GetAllHotels stmt
+	return version, nil
+}
+
+func prepareStatements(ctx context.Context, conn *pgx.Conn) error {
+	const op = "storage.postgres.prepareStatements"
 
-	_, err = conn.Prepare(ctx, "get_all_hotels", `SELECT id, country, city, hotel_name, stars FROM hotels`)
+	// HOTELS TABLE
+
+	// CreateHotel stmt
+	_, err := conn.Prepare(ctx, "create_hotel", `INSERT INTO hotels(country, city, hotel_name, stars) VALUES($1, $2, $3, $4) RETURNING id`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare get_all_hotels failed: %w", op, err)
+		return fmt.Errorf("%s: prepare create_hotel failed: %w", op, err)
 	}
 
 	// GetHotel stmt
 
 	_, err = conn.Prepare(ctx, "get_hotel", `SELECT id, country, city, hotel_name, stars FROM hotels WHERE id = $1`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare get_hotel failed: %w", op, err)
+		return fmt.Errorf("%s: prepare get_hotel failed: %w", op, err)
 	}
 
 	// DeleteHotel stmt
 
 	_, err = conn.Prepare(ctx, "delete_hotel", `DELETE FROM hotels WHERE id = $1`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare delete_hotel failed: %w", op, err)
+		return fmt.Errorf("%s: prepare delete_hotel failed: %w", op, err)
 	}
 
 	//UpdateHotel stmt
 
 	_, err = conn.Prepare(ctx, "update_hotel", `UPDATE hotels SET country = $1, city = $2, hotel_name = $3, stars = $4 WHERE id = $5`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare update_hotel failed: %w", op, err)
+		return fmt.Errorf("%s: prepare update_hotel failed: %w", op, err)
+	}
+
+	// ROOM TYPES TABLE
+
+	// CreateRoomType stmt
+	_, err = conn.Prepare(ctx, "create_room_type", `INSERT INTO room_types(hotel_id, name, description, max_adults, max_children,
+	 bed_configuration, base_occupancy, size_sqm)
+	 VALUES($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare create_room_type failed: %w", op, err)
+	}
+
+	// GetRoomType stmt
+	_, err = conn.Prepare(ctx, "get_room_type", `SELECT `+roomTypeColumns+` FROM room_types WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare get_room_type failed: %w", op, err)
+	}
+
+	// DeleteRoomType stmt
+	_, err = conn.Prepare(ctx, "delete_room_type", `DELETE FROM room_types WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare delete_room_type failed: %w", op, err)
+	}
+
+	// UpdateRoomType stmt
+	_, err = conn.Prepare(ctx, "update_room_type", `UPDATE room_types
+	 SET name = $1, description = $2, max_adults = $3, max_children = $4, bed_configuration = $5,
+	 base_occupancy = $6, size_sqm = $7 WHERE id = $8`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare update_room_type failed: %w", op, err)
+	}
+
+	// RATE PLANS TABLES
+
+	// CreateRatePlan stmts: the plan, then its seasons and stay discounts
+	// from parallel arrays.
+	_, err = conn.Prepare(ctx, "create_rate_plan", `INSERT INTO rate_plans(room_type_id, name, currency,
+	 base_price_cents, weekend_price_cents, extra_guest_price_cents)
+	 VALUES($1, $2, $3, $4, $5, $6) RETURNING id`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare create_rate_plan failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "add_rate_plan_seasons", `INSERT INTO rate_plan_seasons(rate_plan_id, from_date, to_date,
+	 price_cents, weekend_price_cents)
+	 SELECT $1, * FROM unnest($2::date[], $3::date[], $4::bigint[], $5::bigint[])`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare add_rate_plan_seasons failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "add_rate_plan_discounts", `INSERT INTO rate_plan_discounts(rate_plan_id, min_nights, percent)
+	 SELECT $1, * FROM unnest($2::integer[], $3::integer[])`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare add_rate_plan_discounts failed: %w", op, err)
+	}
+
+	// GetRatePlan and ListRatePlans stmts, completed by the seasons and
+	// stay discounts of the plans they return.
+	_, err = conn.Prepare(ctx, "get_rate_plan", `SELECT `+ratePlanColumns+` FROM rate_plans WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare get_rate_plan failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "list_rate_plans", `SELECT `+ratePlanColumns+` FROM rate_plans
+	 WHERE room_type_id = ANY($1::integer[])
+	 ORDER BY id`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare list_rate_plans failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "list_rate_plan_seasons", `SELECT rate_plan_id, from_date, to_date, price_cents, weekend_price_cents
+	 FROM rate_plan_seasons
+	 WHERE rate_plan_id = ANY($1::integer[])
+	 ORDER BY rate_plan_id, from_date`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare list_rate_plan_seasons failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "list_rate_plan_discounts", `SELECT rate_plan_id, min_nights, percent
+	 FROM rate_plan_discounts
+	 WHERE rate_plan_id = ANY($1::integer[])
+	 ORDER BY rate_plan_id, min_nights`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare list_rate_plan_discounts failed: %w", op, err)
+	}
+
+	// UpdateRatePlan stmts: the seasons and stay discounts are replaced.
+	_, err = conn.Prepare(ctx, "update_rate_plan", `UPDATE rate_plans
+	 SET name = $1, currency = $2, base_price_cents = $3, weekend_price_cents = $4, extra_guest_price_cents = $5
+	 WHERE id = $6`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare update_rate_plan failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "clear_rate_plan_seasons", `DELETE FROM rate_plan_seasons WHERE rate_plan_id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare clear_rate_plan_seasons failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "clear_rate_plan_discounts", `DELETE FROM rate_plan_discounts WHERE rate_plan_id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare clear_rate_plan_discounts failed: %w", op, err)
+	}
+
+	// DeleteRatePlan stmt
+	_, err = conn.Prepare(ctx, "delete_rate_plan", `DELETE FROM rate_plans WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare delete_rate_plan failed: %w", op, err)
+	}
+
+	// STAY RESTRICTIONS TABLE
+
+	// ListRestrictions stmt, also used by CreateReservation and
+	// SearchAvailability.
+	_, err = conn.Prepare(ctx, "list_stay_restrictions", `SELECT `+restrictionColumns+` FROM stay_restrictions
+	 WHERE room_type_id = ANY($1::integer[]) AND day BETWEEN $2 AND $3
+	 ORDER BY room_type_id, day`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare list_stay_restrictions failed: %w", op, err)
+	}
+
+	// UpdateRestrictions stmts: NULL keeps the current value, and dates left
+	// without any restriction are removed. Runs under lock_room_type.
+	_, err = conn.Prepare(ctx, "set_stay_restrictions", `INSERT INTO stay_restrictions AS sr
+	 (room_type_id, day, min_stay, max_stay, closed_to_arrival, closed_to_departure, stop_sell)
+	 SELECT $1, day, coalesce($3::integer, 0), coalesce($4::integer, 0),
+	 	coalesce($5::boolean, false), coalesce($6::boolean, false), coalesce($7::boolean, false)
+	 FROM unnest($2::date[]) AS day
+	 ON CONFLICT (room_type_id, day) DO UPDATE SET
+	 	min_stay = coalesce($3, sr.min_stay),
+	 	max_stay = coalesce($4, sr.max_stay),
+	 	closed_to_arrival = coalesce($5, sr.closed_to_arrival),
+	 	closed_to_departure = coalesce($6, sr.closed_to_departure),
+	 	stop_sell = coalesce($7, sr.stop_sell)`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare set_stay_restrictions failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "clear_stay_restrictions", `DELETE FROM stay_restrictions
+	 WHERE room_type_id = $1 AND day = ANY($2::date[])
+	 AND min_stay = 0 AND max_stay = 0 AND NOT (closed_to_arrival OR closed_to_departure OR stop_sell)`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare clear_stay_restrictions failed: %w", op, err)
+	}
+
+	// AMENITIES TABLES
+
+	// CreateAmenity stmt
+	_, err = conn.Prepare(ctx, "create_amenity", `INSERT INTO amenities(code, category, labels) VALUES($1, $2, $3)`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare create_amenity failed: %w", op, err)
+	}
+
+	// ListAmenities stmt: the catalog is small, so one plan for both forms.
+	_, err = conn.Prepare(ctx, "list_amenities", `SELECT `+amenityColumns+` FROM amenities a
+	 WHERE $1 = '' OR a.category = $1
+	 ORDER BY a.category, a.code`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare list_amenities failed: %w", op, err)
+	}
+
+	// GetAmenity stmt
+	_, err = conn.Prepare(ctx, "get_amenity", `SELECT `+amenityColumns+` FROM amenities a WHERE a.code = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare get_amenity failed: %w", op, err)
+	}
+
+	// UpdateAmenity stmt
+	_, err = conn.Prepare(ctx, "update_amenity", `UPDATE amenities SET category = $1, labels = $2 WHERE code = $3`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare update_amenity failed: %w", op, err)
+	}
+
+	// DeleteAmenity stmt
+	_, err = conn.Prepare(ctx, "delete_amenity", `DELETE FROM amenities WHERE code = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare delete_amenity failed: %w", op, err)
+	}
+
+	// ListHotelAmenities and SetHotelAmenities stmts: the hotel row
+	// serialises concurrent replacements of its list.
+	_, err = conn.Prepare(ctx, "list_hotel_amenities", `SELECT `+amenityColumns+` FROM amenities a
+	 JOIN hotel_amenities ha ON ha.amenity_code = a.code
+	 WHERE ha.hotel_id = $1
+	 ORDER BY a.category, a.code`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare list_hotel_amenities failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "lock_hotel", `SELECT id FROM hotels WHERE id = $1 FOR UPDATE`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare lock_hotel failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "clear_hotel_amenities", `DELETE FROM hotel_amenities WHERE hotel_id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare clear_hotel_amenities failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "add_hotel_amenities", `INSERT INTO hotel_amenities(hotel_id, amenity_code)
+	 SELECT $1, code FROM unnest($2::text[]) AS code
+	 ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare add_hotel_amenities failed: %w", op, err)
+	}
+
+	// ListRoomTypeAmenities and SetRoomTypeAmenities stmts, locking with
+	// lock_room_type.
+	_, err = conn.Prepare(ctx, "list_room_type_amenities", `SELECT `+amenityColumns+` FROM amenities a
+	 JOIN room_type_amenities rta ON rta.amenity_code = a.code
+	 WHERE rta.room_type_id = $1
+	 ORDER BY a.category, a.code`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare list_room_type_amenities failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "clear_room_type_amenities", `DELETE FROM room_type_amenities WHERE room_type_id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare clear_room_type_amenities failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "add_room_type_amenities", `INSERT INTO room_type_amenities(room_type_id, amenity_code)
+	 SELECT $1, code FROM unnest($2::text[]) AS code
+	 ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare add_room_type_amenities failed: %w", op, err)
 	}
 
 	//HOTELROOMS TABLE
 
 	// CreateHotelRoom stmt
-	_, err = conn.Prepare(ctx, "create_hotel_room", `INSERT INTO hotel_rooms(hotel_id, rooms, meals, bar, service, busy)
-	 VALUES($1, $2, $3, $4, $5, $6)`)
+	_, err = conn.Prepare(ctx, "create_hotel_room", `INSERT INTO hotel_rooms(hotel_id, room_type_id, room_number, floor)
+	 VALUES($1, $2, $3, $4) RETURNING id`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare create_hotel_room failed: %w", op, err)
+		return fmt.Errorf("%s: prepare create_hotel_room failed: %w", op, err)
 	}
 
-	// GetAllHotelRooms stmt
-	_, err = conn.Prepare(ctx, "get_all_hotel_rooms", `SELECT id, hotel_id, rooms, meals, bar, service, busy FROM hotel_rooms`)
+	// GetHotelRoom stmt
+	_, err = conn.Prepare(ctx, "get_hotel_room", `SELECT id, hotel_id, room_type_id, room_number, floor FROM hotel_rooms WHERE id = $1`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare get_all_hotel_rooms failed: %w", op, err)
+		return fmt.Errorf("%s: prepare get_hotel_room failed: %w", op, err)
 	}
 
-	// GetHotelRoom stmt
-	_, err = conn.Prepare(ctx, "get_hotel_room", `SELECT * FROM hotel_rooms WHERE id = $1`)
+	// CreateVisitor and UpdateVisitor stmt, also used by CheckInReservation:
+	// tells a missing room from a room of another hotel or type.
+	_, err = conn.Prepare(ctx, "hotel_room_exists", `SELECT EXISTS (SELECT 1 FROM hotel_rooms WHERE id = $1)`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare get_hotel_room failed: %w", op, err)
+		return fmt.Errorf("%s: prepare hotel_room_exists failed: %w", op, err)
 	}
 
 	// DeleteHotelRoom stmt
 	_, err = conn.Prepare(ctx, "delete_hotel_room", `DELETE FROM hotel_rooms WHERE id = $1`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare delete_hotel_room failed: %w", op, err)
+		return fmt.Errorf("%s: prepare delete_hotel_room failed: %w", op, err)
 	}
 
 	// UpdateHotelRoom stmt
 	_, err = conn.Prepare(ctx, "update_hotel_room", `UPDATE hotel_rooms
-	 SET hotel_id = $1, rooms = $2, meals = $3, bar = $4, service = $5 WHERE id = $6`)
+	 SET hotel_id = $1, room_type_id = $2, room_number = $3, floor = $4 WHERE id = $5`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare update_hotel_room failed: %w", op, err)
+		return fmt.Errorf("%s: prepare update_hotel_room failed: %w", op, err)
 	}
 
 	// VISITORS TABLE
 
 	// CreateVisitor stmt
 
-	_, err = conn.Prepare(ctx, "create_visitor", `INSERT INTO visitors(hotel_id, hotel_room_id, first_name, last_name, age) VALUES($1, $2, $3, $4, $5)`)
+	_, err = conn.Prepare(ctx, "create_visitor", `INSERT INTO visitors(hotel_id, hotel_room_id, first_name, last_name, age) VALUES($1, $2, $3, $4, $5) RETURNING id`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare create_vivstor failed: %w", op, err)
-	}
-
-	// GetAllVisitors stmt
-
-	_, err = conn.Prepare(ctx, "get_all_visitors", `SELECT id, hotel_id, hotel_room_id, first_name, last_name, age FROM visitors`)
-	if err != nil {
-		return nil, fmt.Errorf("%s: prepare get_all_visitors failed: %w", op, err)
+		return fmt.Errorf("%s: prepare create_visitor failed: %w", op, err)
 	}
 
 	// GetVisitor stmt
 
 	_, err = conn.Prepare(ctx, "get_visitor", `SELECT id, hotel_id, hotel_room_id, first_name, last_name, age FROM visitors WHERE id = $1`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare get_visitor failed: %w", op, err)
+		return fmt.Errorf("%s: prepare get_visitor failed: %w", op, err)
 	}
 
 	// DeleteVisitor stmt
 
 	_, err = conn.Prepare(ctx, "delete_visitor", `DELETE FROM visitors WHERE id = $1`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare delete_visitor failed: %w", op, err)
+		return fmt.Errorf("%s: prepare delete_visitor failed: %w", op, err)
 	}
 
 	// UpdateVisitor stmt
 
-	_, err = conn.Prepare(ctx, "update_visitor", `UPDATE visitors SET hotel_id = $1, hotel_room_id = $2, first_name = $3, last_name = $4, age = $5`)
+	_, err = conn.Prepare(ctx, "update_visitor", `UPDATE visitors SET hotel_id = $1, hotel_room_id = $2, first_name = $3, last_name = $4, age = $5 WHERE id = $6`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare update_visitor failed: %w", op, err)
+	}
+
+	// RESERVATIONS TABLE
+
+	// CreateReservation stmt
+
+	_, err = conn.Prepare(ctx, "create_reservation", `INSERT INTO reservations(room_type_id, visitor_id, check_in, check_out, guests,
+	 rate_plan_id, currency, total_cents, status, expires_at)
+	 VALUES($1, $2, $3, $4, $5, NULLIF($6, 0), $7, $8, $9, now() + $10::bigint * interval '1 microsecond') RETURNING id`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare create_reservation failed: %w", op, err)
+	}
+
+	// GetReservation stmt
+
+	_, err = conn.Prepare(ctx, "get_reservation", `SELECT `+reservationColumns+` FROM reservations WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare get_reservation failed: %w", op, err)
+	}
+
+	// CancelReservation stmt
+
+	_, err = conn.Prepare(ctx, "cancel_reservation", `UPDATE reservations SET status = 'cancelled'
+	 WHERE id = $1 AND status IN ('held', 'pending', 'confirmed')`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare cancel_reservation failed: %w", op, err)
+	}
+
+	// ConfirmReservation stmt: old is the row before the update, locked, so
+	// the status it returns tells a confirmed hold from a pending reservation.
+	_, err = conn.Prepare(ctx, "confirm_reservation", `UPDATE reservations r SET status = 'confirmed', expires_at = NULL
+	 FROM (SELECT id, status FROM reservations WHERE id = $1 FOR UPDATE) old
+	 WHERE r.id = old.id AND (r.status = 'pending' OR (r.status = 'held' AND r.expires_at > now()))
+	 RETURNING old.status`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare confirm_reservation failed: %w", op, err)
+	}
+
+	// ExpireHolds stmt: SKIP LOCKED lets several sweepers share the work.
+	_, err = conn.Prepare(ctx, "expire_holds", `WITH due AS (
+		SELECT id FROM reservations
+		WHERE status = 'held' AND expires_at <= now()
+		ORDER BY expires_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	 )
+	 UPDATE reservations r SET status = 'expired' FROM due WHERE r.id = due.id`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare expire_holds failed: %w", op, err)
+	}
+
+	// CreateReservation stmts: the room type row serialises bookings of the
+	// type, then the free rooms are counted night by night.
+	_, err = conn.Prepare(ctx, "lock_room_type", `SELECT `+roomTypeColumns+` FROM room_types WHERE id = $1 FOR UPDATE`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare lock_room_type failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "room_type_available", `SELECT `+roomTypeAvailable("$1", "$2", "$3"))
+	if err != nil {
+		return fmt.Errorf("%s: prepare room_type_available failed: %w", op, err)
+	}
+
+	// CheckInReservation stmts
+	_, err = conn.Prepare(ctx, "lock_reservation", `SELECT `+reservationColumns+` FROM reservations WHERE id = $1 FOR UPDATE`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare lock_reservation failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "free_hotel_room", `SELECT hr.id FROM hotel_rooms hr
+	 WHERE hr.room_type_id = $1
+	 AND NOT EXISTS (
+		SELECT 1 FROM reservations r
+		WHERE r.hotel_room_id = hr.id
+		AND r.status <> 'cancelled'
+		AND daterange(r.check_in, r.check_out) && daterange($2::date, $3::date)
+	 )
+	 ORDER BY hr.room_number
+	 LIMIT 1`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare free_hotel_room failed: %w", op, err)
+	}
+
+	_, err = conn.Prepare(ctx, "check_in_reservation", `UPDATE reservations SET hotel_room_id = $1, status = 'checked_in' WHERE id = $2`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare update_visitor failed: %w", op, err)
+		return fmt.Errorf("%s: prepare check_in_reservation failed: %w", op, err)
 	}
 
-	return &Postgres{conn: conn}, nil
+	return nil
 }
@@ -0,0 +1,52 @@
+package storage_test
+
+import (
+	"bookings/internal/config"
+	"bookings/internal/storage"
+	"bookings/internal/storage/storagetest"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TestPostgresRepositoryContract runs against BOOKINGS_TEST_DATABASE_URL. Every
+// subtest truncates all tables, so point it at a scratch database only.
+func TestPostgresRepositoryContract(t *testing.T) {
+	url := os.Getenv("BOOKINGS_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("BOOKINGS_TEST_DATABASE_URL is not set")
+	}
+
+	storagetest.Migrate(t, url)
+
+	pos, err := storage.NewPostgresDb(&config.Config{
+		DatabaseUrl:             url,
+		DatabaseMaxConns:        4,
+		DatabaseMaxConnLifetime: time.Hour,
+		DatabaseMaxConnIdleTime: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("failed to init storage: %v", err)
+	}
+	t.Cleanup(pos.Close)
+
+	storagetest.RunRepositoryContract(t, func(t *testing.T) storage.Repository {
+		ctx := context.Background()
+
+		conn, err := pgx.Connect(ctx, url)
+		if err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+		defer conn.Close(ctx)
+
+		_, err = conn.Exec(ctx, `TRUNCATE reservations, visitors, hotel_rooms, stay_restrictions, rate_plans, room_types, hotels, amenities RESTART IDENTITY CASCADE`)
+		if err != nil {
+			t.Fatalf("truncate failed: %v", err)
+		}
+
+		return pos
+	})
+}
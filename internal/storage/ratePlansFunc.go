@@ -0,0 +1,342 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/pricing"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const ratePlanColumns = `id, room_type_id, name, currency, base_price_cents, weekend_price_cents, extra_guest_price_cents`
+
+func (pos *Postgres) CreateRatePlan(ctx context.Context, rp models.RatePlan) (_ models.RatePlan, err error) {
+	const op = "storage.postgres.CreateRatePlan"
+	defer observe(ctx, op, time.Now(), &err)
+
+	if err := CheckRatePlan(rp); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := pos.pool.Begin(ctx)
+	if err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: begin failed: %w", op, pgError(err))
+	}
+	defer tx.Rollback(ctx)
+
+	var id int
+	err = tx.QueryRow(ctx, "create_rate_plan", rp.RoomTypeId, rp.Name, rp.Currency,
+		int64(rp.BasePrice), int64(rp.WeekendPrice), int64(rp.ExtraGuestPrice)).Scan(&id)
+	if err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: exec failed: %w", op, ratePlanWriteErr(err))
+	}
+
+	if err = addRateDetails(ctx, tx, id, rp); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: commit failed: %w", op, pgError(err))
+	}
+
+	return pos.GetRatePlan(ctx, id)
+}
+
+func (pos *Postgres) ListRatePlans(ctx context.Context, roomTypeId int) (_ []models.RatePlan, err error) {
+	const op = "storage.postgres.ListRatePlans"
+	defer observe(ctx, op, time.Now(), &err)
+
+	plans, err := loadRatePlans(ctx, pos.pool, "list_rate_plans", []int{roomTypeId})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// An empty list may also mean there is no such room type.
+	if len(plans) == 0 {
+		if _, err := pos.GetRoomType(ctx, roomTypeId); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return plans, nil
+}
+
+func (pos *Postgres) GetRatePlan(ctx context.Context, id int) (_ models.RatePlan, err error) {
+	const op = "storage.postgres.GetRatePlan"
+	defer observe(ctx, op, time.Now(), &err)
+
+	plans, err := loadRatePlans(ctx, pos.pool, "get_rate_plan", id)
+	if err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(plans) == 0 {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, ErrRatePlanNotFound)
+	}
+
+	return plans[0], nil
+}
+
+// UpdateRatePlan replaces the prices, seasons and stay discounts of a plan.
+// Reservations keep the total they were quoted.
+func (pos *Postgres) UpdateRatePlan(ctx context.Context, id int, rp models.RatePlan) (_ models.RatePlan, err error) {
+	const op = "storage.postgres.UpdateRatePlan"
+	defer observe(ctx, op, time.Now(), &err)
+
+	if err := CheckRatePlan(rp); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := pos.pool.Begin(ctx)
+	if err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: begin failed: %w", op, pgError(err))
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, "update_rate_plan", rp.Name, rp.Currency,
+		int64(rp.BasePrice), int64(rp.WeekendPrice), int64(rp.ExtraGuestPrice), id)
+	if err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: update failed: %w", op, ratePlanWriteErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, ErrRatePlanNotFound)
+	}
+
+	if _, err = tx.Exec(ctx, "clear_rate_plan_seasons", id); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: delete failed: %w", op, pgError(err))
+	}
+	if _, err = tx.Exec(ctx, "clear_rate_plan_discounts", id); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: delete failed: %w", op, pgError(err))
+	}
+
+	if err = addRateDetails(ctx, tx, id, rp); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.RatePlan{}, fmt.Errorf("%s: commit failed: %w", op, pgError(err))
+	}
+
+	return pos.GetRatePlan(ctx, id)
+}
+
+func (pos *Postgres) DeleteRatePlan(ctx context.Context, id int) (err error) {
+	const op = "storage.postgres.DeleteRatePlan"
+	defer observe(ctx, op, time.Now(), &err)
+
+	tag, err := pos.pool.Exec(ctx, "delete_rate_plan", id)
+	if err != nil {
+		if pgErrCode(err) == pgForeignKeyViolation {
+			return fmt.Errorf("%s: %w", op, ErrRatePlanHasReservations)
+		}
+		return fmt.Errorf("%s: delete failed: %w", op, pgError(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrRatePlanNotFound)
+	}
+
+	return nil
+}
+
+func (pos *Postgres) QuoteStay(ctx context.Context, roomTypeId int, ratePlanId int, checkIn models.Date, checkOut models.Date, guests int) (_ models.Quote, err error) {
+	const op = "storage.postgres.QuoteStay"
+	defer observe(ctx, op, time.Now(), &err)
+
+	if guests <= 0 || !checkOut.After(checkIn.Time) || StayTooLong(checkIn, checkOut) {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, ErrReservationInvalid)
+	}
+
+	rt, err := pos.GetRoomType(ctx, roomTypeId)
+	if err != nil {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if guests > rt.MaxGuests() {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, ErrReservationTooManyGuests)
+	}
+
+	plans, err := loadRatePlans(ctx, pos.pool, "list_rate_plans", []int{roomTypeId})
+	if err != nil {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	quote, err := PriceStay(plans, rt, ratePlanId, checkIn, checkOut, guests)
+	if err != nil {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return quote, nil
+}
+
+// CheckRatePlan validates what the rate plan CHECKs do not: the precision of
+// the currency and the MaxPrice bound of every price. Both repositories
+// check plans through it.
+func CheckRatePlan(rp models.RatePlan) error {
+	if !models.TwoDecimalCurrency(rp.Currency) {
+		return ErrRatePlanCurrency
+	}
+	if rp.BasePrice > models.MaxPrice || rp.WeekendPrice > models.MaxPrice || rp.ExtraGuestPrice > models.MaxPrice {
+		return ErrRatePlanInvalid
+	}
+	for _, season := range rp.Seasons {
+		if season.Price > models.MaxPrice || season.WeekendPrice > models.MaxPrice {
+			return ErrRatePlanSeasonInvalid
+		}
+	}
+	return nil
+}
+
+// PriceStay quotes a stay in roomType under its plan ratePlanId, or under
+// the cheapest of its plans for ratePlanId 0. plans are the plans of the
+// type. Both repositories price through it.
+func PriceStay(plans []models.RatePlan, roomType models.RoomType, ratePlanId int, checkIn, checkOut models.Date, guests int) (models.Quote, error) {
+	if ratePlanId == 0 {
+		quote, ok, err := pricing.Cheapest(plans, roomType, checkIn, checkOut, guests)
+		if err != nil {
+			return models.Quote{}, ErrStayPriceOverflow
+		}
+		if !ok {
+			return models.Quote{}, ErrRoomTypeNotPriced
+		}
+		return quote, nil
+	}
+
+	for _, plan := range plans {
+		if plan.Id == ratePlanId {
+			quote, err := pricing.Quote(plan, roomType, checkIn, checkOut, guests)
+			if err != nil {
+				return models.Quote{}, ErrStayPriceOverflow
+			}
+			return quote, nil
+		}
+	}
+	return models.Quote{}, ErrRatePlanNotOffered
+}
+
+// addRateDetails inserts the seasons and stay discounts of rp for the plan
+// id.
+func addRateDetails(ctx context.Context, tx pgx.Tx, id int, rp models.RatePlan) error {
+	var (
+		from, to              []time.Time
+		prices, weekendPrices []int64
+	)
+	for _, s := range rp.Seasons {
+		if s.From.IsZero() || s.To.IsZero() {
+			return ErrRatePlanSeasonInvalid
+		}
+		from, to = append(from, s.From.Time), append(to, s.To.Time)
+		prices, weekendPrices = append(prices, int64(s.Price)), append(weekendPrices, int64(s.WeekendPrice))
+	}
+
+	if _, err := tx.Exec(ctx, "add_rate_plan_seasons", id, from, to, prices, weekendPrices); err != nil {
+		return fmt.Errorf("exec failed: %w", ratePlanWriteErr(err))
+	}
+
+	var minNights, percents []int
+	for _, d := range rp.StayDiscounts {
+		minNights, percents = append(minNights, d.MinNights), append(percents, d.Percent)
+	}
+
+	if _, err := tx.Exec(ctx, "add_rate_plan_discounts", id, minNights, percents); err != nil {
+		return fmt.Errorf("exec failed: %w", ratePlanWriteErr(err))
+	}
+
+	return nil
+}
+
+// loadRatePlans runs stmt, which selects ratePlanColumns ordered by id, and
+// fills in the seasons and stay discounts of the plans it returns.
+func loadRatePlans(ctx context.Context, db querier, stmt string, arg any) ([]models.RatePlan, error) {
+	rows, err := db.Query(ctx, stmt, arg)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", pgError(err))
+	}
+
+	plans, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.RatePlan, error) {
+		var rp models.RatePlan
+		err := row.Scan(&rp.Id, &rp.RoomTypeId, &rp.Name, &rp.Currency,
+			(*int64)(&rp.BasePrice), (*int64)(&rp.WeekendPrice), (*int64)(&rp.ExtraGuestPrice))
+		rp.Seasons, rp.StayDiscounts = []models.Season{}, []models.StayDiscount{}
+		return rp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", pgError(err))
+	}
+	if len(plans) == 0 {
+		return plans, nil
+	}
+
+	ids := make([]int, 0, len(plans))
+	byId := make(map[int]*models.RatePlan, len(plans))
+	for i := range plans {
+		ids = append(ids, plans[i].Id)
+		byId[plans[i].Id] = &plans[i]
+	}
+
+	rows, err = db.Query(ctx, "list_rate_plan_seasons", ids)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", pgError(err))
+	}
+	_, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (struct{}, error) {
+		var (
+			planId   int
+			from, to time.Time
+			s        models.Season
+		)
+		if err := row.Scan(&planId, &from, &to, (*int64)(&s.Price), (*int64)(&s.WeekendPrice)); err != nil {
+			return struct{}{}, err
+		}
+		s.From, s.To = models.NewDate(from), models.NewDate(to)
+		byId[planId].Seasons = append(byId[planId].Seasons, s)
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", pgError(err))
+	}
+
+	rows, err = db.Query(ctx, "list_rate_plan_discounts", ids)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", pgError(err))
+	}
+	_, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (struct{}, error) {
+		var (
+			planId int
+			d      models.StayDiscount
+		)
+		if err := row.Scan(&planId, &d.MinNights, &d.Percent); err != nil {
+			return struct{}{}, err
+		}
+		byId[planId].StayDiscounts = append(byId[planId].StayDiscounts, d)
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", pgError(err))
+	}
+
+	return plans, nil
+}
+
+// ratePlanWriteErr translates constraint violations raised by rate plan
+// writes, including those of its seasons and stay discounts.
+func ratePlanWriteErr(err error) error {
+	constraint := pgConstraint(err)
+
+	switch code := pgErrCode(err); {
+	case code == pgForeignKeyViolation && constraint == "rate_plans_room_type_id_fkey":
+		return ErrRoomTypeNotFound
+	case code == pgUniqueViolation && constraint == "rate_plans_room_type_id_name_key":
+		return ErrRatePlanExists
+	case strings.HasPrefix(constraint, "rate_plan_seasons_"):
+		return ErrRatePlanSeasonInvalid
+	case strings.HasPrefix(constraint, "rate_plan_discounts_"):
+		return ErrRatePlanDiscountInvalid
+	case code == pgCheckViolation:
+		return ErrRatePlanInvalid
+	}
+	return pgError(err)
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"context"
+	"time"
+)
+
+// HotelRepository manages hotels. Hotel names are unique and stars are
+// between 1 and 5. A hotel cannot be deleted while it still has rooms or
+// room types; its amenity links are deleted with it.
+type HotelRepository interface {
+	CreateHotel(ctx context.Context, country string, city string, hotelName string, stars int) (models.Hotel, error)
+	ListHotels(ctx context.Context, filter HotelFilter) (models.Page[models.Hotel], error)
+	GetHotel(ctx context.Context, id int) (models.Hotel, error)
+	UpdateHotel(ctx context.Context, id int, country string, city string, hotelName string, stars int) (models.Hotel, error)
+	DeleteHotel(ctx context.Context, id int) error
+}
+
+// RoomTypeRepository manages room types. Names are unique within a hotel and
+// the base occupancy lies within the capacity. A room type cannot be deleted
+// while rooms or reservations reference it; its hotel never changes.
+type RoomTypeRepository interface {
+	CreateRoomType(ctx context.Context, rt models.RoomType) (models.RoomType, error)
+	ListRoomTypes(ctx context.Context, filter RoomTypeFilter) (models.Page[models.RoomType], error)
+	GetRoomType(ctx context.Context, id int) (models.RoomType, error)
+	UpdateRoomType(ctx context.Context, id int, rt models.RoomType) (models.RoomType, error)
+	DeleteRoomType(ctx context.Context, id int) error
+}
+
+// RatePlanRepository manages the rate plans of room types and quotes stays
+// with them, see pricing.Quote. Plan names are unique within a room type and
+// a plan cannot be deleted while reservations were priced with it. Prices
+// are at most models.MaxPrice. QuoteStay uses the plan ratePlanId, or the
+// cheapest plan of the type for 0.
+type RatePlanRepository interface {
+	CreateRatePlan(ctx context.Context, rp models.RatePlan) (models.RatePlan, error)
+	ListRatePlans(ctx context.Context, roomTypeId int) ([]models.RatePlan, error)
+	GetRatePlan(ctx context.Context, id int) (models.RatePlan, error)
+	UpdateRatePlan(ctx context.Context, id int, rp models.RatePlan) (models.RatePlan, error)
+	DeleteRatePlan(ctx context.Context, id int) error
+	QuoteStay(ctx context.Context, roomTypeId int, ratePlanId int, checkIn models.Date, checkOut models.Date, guests int) (models.Quote, error)
+}
+
+// RestrictionRepository manages the stay restrictions of room types, which
+// CreateReservation and SearchAvailability enforce through CheckStay.
+// ListRestrictions returns the dates from from to to, both included, that
+// restrict anything; UpdateRestrictions applies an update to a date range and
+// returns the restrictions of the range afterwards.
+type RestrictionRepository interface {
+	ListRestrictions(ctx context.Context, roomTypeId int, from models.Date, to models.Date) ([]models.StayRestriction, error)
+	UpdateRestrictions(ctx context.Context, roomTypeId int, u models.RestrictionUpdate) ([]models.StayRestriction, error)
+}
+
+// AmenityRepository manages the amenity catalog and which amenities hotels
+// and room types offer. An amenity cannot be deleted while it is offered.
+// The Set methods replace the whole list and return it; unknown codes fail
+// with ErrAmenityNotFound.
+type AmenityRepository interface {
+	CreateAmenity(ctx context.Context, a models.Amenity) (models.Amenity, error)
+	ListAmenities(ctx context.Context, filter AmenityFilter) ([]models.Amenity, error)
+	GetAmenity(ctx context.Context, code string) (models.Amenity, error)
+	UpdateAmenity(ctx context.Context, code string, a models.Amenity) (models.Amenity, error)
+	DeleteAmenity(ctx context.Context, code string) error
+	ListHotelAmenities(ctx context.Context, hotelId int) ([]models.Amenity, error)
+	SetHotelAmenities(ctx context.Context, hotelId int, codes []string) ([]models.Amenity, error)
+	ListRoomTypeAmenities(ctx context.Context, roomTypeId int) ([]models.Amenity, error)
+	SetRoomTypeAmenities(ctx context.Context, roomTypeId int, codes []string) ([]models.Amenity, error)
+}
+
+// HotelRoomRepository manages physical rooms. Every room belongs to an
+// existing hotel and a room type of that hotel, its number is unique within
+// the hotel, and it cannot be deleted while visitors or reservations
+// reference it. Moving a room to another hotel moves its visitors along.
+type HotelRoomRepository interface {
+	CreateHotelRoom(ctx context.Context, hotelId int, roomTypeId int, roomNumber int, floor int) (models.HotelRoom, error)
+	ListHotelRooms(ctx context.Context, filter HotelRoomFilter) (models.Page[models.HotelRoom], error)
+	GetHotelRoom(ctx context.Context, id int) (models.HotelRoom, error)
+	UpdateHotelRoom(ctx context.Context, id int, hotelId int, roomTypeId int, roomNumber int, floor int) (models.HotelRoom, error)
+	DeleteHotelRoom(ctx context.Context, id int) error
+}
+
+// VisitorRepository manages visitors. Every visitor is placed in an existing
+// room of their hotel and is between 18 and 100 years old.
+type VisitorRepository interface {
+	CreateVisitor(ctx context.Context, hotelId int, hotelRoom int, firstName string, lastName string, age int) (models.Visitor, error)
+	ListVisitors(ctx context.Context, filter VisitorFilter) (models.Page[models.Visitor], error)
+	GetVisitor(ctx context.Context, id int) (models.Visitor, error)
+	UpdateVisitor(ctx context.Context, id int, hotelId int, hotelRoom int, firstName string, lastName string, age int) (models.Visitor, error)
+	DeleteVisitor(ctx context.Context, id int) error
+}
+
+// ReservationRepository manages reservations. A reservation books a room
+// type; on no night do the live reservations of a type outnumber its rooms,
+// where live means neither cancelled nor expired nor a hold past its expiry.
+// CheckInReservation assigns the given room, or any free room of the type for
+// hotelRoomId 0; assigned rooms never overlap. CreateReservation prices the
+// stay like QuoteStay when the type has rate plans and stores the plan and
+// total with the reservation.
+//
+// HoldReservation books like CreateReservation but only for holdFor, unless
+// ConfirmReservation confirms the hold in time. ExpireHolds marks up to limit
+// holds that have run out as expired and returns how many it marked; it is
+// safe to run from several instances at once.
+type ReservationRepository interface {
+	CreateReservation(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int) (models.Reservation, error)
+	HoldReservation(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int, holdFor time.Duration) (models.Reservation, error)
+	ConfirmReservation(ctx context.Context, id int) (models.Reservation, error)
+	ExpireHolds(ctx context.Context, limit int) (int, error)
+	ListReservations(ctx context.Context, filter ReservationFilter) (models.Page[models.Reservation], error)
+	GetReservation(ctx context.Context, id int) (models.Reservation, error)
+	CancelReservation(ctx context.Context, id int) (models.Reservation, error)
+	CheckInReservation(ctx context.Context, id int, hotelRoomId int) (models.Reservation, error)
+}
+
+type AvailabilityRepository interface {
+	SearchAvailability(ctx context.Context, filter AvailabilityFilter) ([]models.HotelAvailability, error)
+}
+
+// Repository is everything the HTTP layer needs from storage. List methods
+// return one keyset page at a time, see PageRequest.
+type Repository interface {
+	HotelRepository
+	RoomTypeRepository
+	RatePlanRepository
+	RestrictionRepository
+	AmenityRepository
+	HotelRoomRepository
+	VisitorRepository
+	ReservationRepository
+	AvailabilityRepository
+}
+
+var _ Repository = (*Postgres)(nil)
@@ -0,0 +1,405 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const reservationColumns = `id, room_type_id, coalesce(hotel_room_id, 0), visitor_id, check_in, check_out, guests, status, created_at,
+	coalesce(rate_plan_id, 0), currency, total_cents, expires_at`
+
+// CreateReservation books a room of the type if one is free on every night of
+// the stay. Types without rate plans are booked without a price.
+func (pos *Postgres) CreateReservation(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int) (_ models.Reservation, err error) {
+	const op = "storage.postgres.CreateReservation"
+	defer observe(ctx, op, time.Now(), &err)
+
+	res, err := pos.reserve(ctx, roomTypeId, ratePlanId, visitorId, checkIn, checkOut, guests, 0)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, err)
+	}
+	reservationsCreated.Inc()
+
+	return res, nil
+}
+
+// HoldReservation reserves a room of the type like CreateReservation while
+// the guest checks out. The hold blocks the room type for holdFor, counted
+// by the database clock.
+func (pos *Postgres) HoldReservation(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int, holdFor time.Duration) (_ models.Reservation, err error) {
+	const op = "storage.postgres.HoldReservation"
+	defer observe(ctx, op, time.Now(), &err)
+
+	if holdFor <= 0 {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, ErrHoldInvalid)
+	}
+
+	res, err := pos.reserve(ctx, roomTypeId, ratePlanId, visitorId, checkIn, checkOut, guests, holdFor)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, err)
+	}
+	holdsCreated.Inc()
+
+	return res, nil
+}
+
+// reserve inserts a reservation, held for holdFor or booked for 0. Locking
+// the room type row serialises concurrent bookings of the type between the
+// count and the insert, and against restriction updates.
+func (pos *Postgres) reserve(ctx context.Context, roomTypeId int, ratePlanId int, visitorId int, checkIn models.Date, checkOut models.Date, guests int, holdFor time.Duration) (models.Reservation, error) {
+	if guests <= 0 || !checkOut.After(checkIn.Time) || StayTooLong(checkIn, checkOut) {
+		return models.Reservation{}, ErrReservationInvalid
+	}
+
+	tx, err := pos.pool.Begin(ctx)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("begin failed: %w", pgError(err))
+	}
+	defer tx.Rollback(ctx)
+
+	rt, err := scanRoomType(tx.QueryRow(ctx, "lock_room_type", roomTypeId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Reservation{}, ErrRoomTypeNotFound
+		}
+		return models.Reservation{}, fmt.Errorf("lock failed: %w", pgError(err))
+	}
+
+	if guests > rt.MaxGuests() {
+		return models.Reservation{}, ErrReservationTooManyGuests
+	}
+
+	restrictions, err := queryRestrictions(ctx, tx, []int{roomTypeId}, checkIn, checkOut)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	if err = CheckStay(restrictions, checkIn, checkOut); err != nil {
+		return models.Reservation{}, err
+	}
+
+	var available int
+	err = tx.QueryRow(ctx, "room_type_available", roomTypeId, checkIn.Time, checkOut.Time).Scan(&available)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("query failed: %w", pgError(err))
+	}
+
+	if available <= 0 {
+		return models.Reservation{}, ErrRoomTypeSoldOut
+	}
+
+	plans, err := loadRatePlans(ctx, tx, "list_rate_plans", []int{roomTypeId})
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	var quote models.Quote
+	if ratePlanId != 0 || len(plans) > 0 {
+		quote, err = PriceStay(plans, rt, ratePlanId, checkIn, checkOut, guests)
+		if err != nil {
+			return models.Reservation{}, err
+		}
+	}
+
+	status, expiresIn := models.ReservationPending, (*int64)(nil)
+	if holdFor > 0 {
+		micros := holdFor.Microseconds()
+		status, expiresIn = models.ReservationHeld, &micros
+	}
+
+	var id int
+	err = tx.QueryRow(ctx, "create_reservation", roomTypeId, visitorId, checkIn.Time, checkOut.Time, guests,
+		quote.RatePlanId, quote.Currency, int64(quote.Total), status, expiresIn).Scan(&id)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("exec failed: %w", reservationWriteErr(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Reservation{}, fmt.Errorf("commit failed: %w", pgError(err))
+	}
+
+	return pos.GetReservation(ctx, id)
+}
+
+// ReservationFilter selects reservations for ListReservations. Zero fields do
+// not filter; CheckInFrom and CheckInTo bound check_in inclusively.
+// HotelRoomId matches reservations that were assigned the room at check-in.
+type ReservationFilter struct {
+	PageRequest
+	HotelId     int                      `form:"hotel_id" binding:"omitempty,gt=0"`
+	RoomTypeId  int                      `form:"room_type_id" binding:"omitempty,gt=0"`
+	HotelRoomId int                      `form:"hotel_room_id" binding:"omitempty,gt=0"`
+	VisitorId   int                      `form:"visitor_id" binding:"omitempty,gt=0"`
+	Status      models.ReservationStatus `form:"status" binding:"omitempty,oneof=held expired pending confirmed cancelled checked_in checked_out"`
+	CheckInFrom models.Date              `form:"check_in_from"`
+	CheckInTo   models.Date              `form:"check_in_to"`
+}
+
+// ReservationSortFields are the fields reservations can be sorted by.
+var ReservationSortFields = map[string]SortField[models.Reservation]{
+	"id":         {Column: "id", Value: func(r models.Reservation) any { return r.Id }},
+	"check_in":   {Column: "check_in", Value: func(r models.Reservation) any { return r.CheckIn.Time }},
+	"check_out":  {Column: "check_out", Value: func(r models.Reservation) any { return r.CheckOut.Time }},
+	"created_at": {Column: "created_at", Value: func(r models.Reservation) any { return r.CreatedAt }},
+}
+
+func (pos *Postgres) ListReservations(ctx context.Context, filter ReservationFilter) (_ models.Page[models.Reservation], err error) {
+	const op = "storage.postgres.ListReservations"
+	defer observe(ctx, op, time.Now(), &err)
+
+	k, err := NewKeyset(filter.PageRequest, ReservationSortFields, "check_in")
+	if err != nil {
+		return models.Page[models.Reservation]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var q listQuery
+	if filter.HotelId != 0 {
+		q.filter("room_type_id IN (SELECT id FROM room_types WHERE hotel_id = %s)", filter.HotelId)
+	}
+	if filter.RoomTypeId != 0 {
+		q.filter("room_type_id = %s", filter.RoomTypeId)
+	}
+	if filter.HotelRoomId != 0 {
+		q.filter("hotel_room_id = %s", filter.HotelRoomId)
+	}
+	if filter.VisitorId != 0 {
+		q.filter("visitor_id = %s", filter.VisitorId)
+	}
+	if filter.Status != "" {
+		q.filter("status = %s", filter.Status)
+	}
+	if !filter.CheckInFrom.IsZero() {
+		q.filter("check_in >= %s", filter.CheckInFrom.Time)
+	}
+	if !filter.CheckInTo.IsZero() {
+		q.filter("check_in <= %s", filter.CheckInTo.Time)
+	}
+
+	query := build(&q, "SELECT "+reservationColumns+" FROM reservations", k)
+
+	rows, err := pos.pool.Query(ctx, query, q.args...)
+	if err != nil {
+		return models.Page[models.Reservation]{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+
+	reservations, err := scanReservations(op, rows)
+	if err != nil {
+		return models.Page[models.Reservation]{}, err
+	}
+
+	return k.Page(reservations), nil
+}
+
+func (pos *Postgres) GetReservation(ctx context.Context, id int) (_ models.Reservation, err error) {
+	const op = "storage.postgres.GetReservation"
+	defer observe(ctx, op, time.Now(), &err)
+
+	res, err := scanReservation(pos.pool.QueryRow(ctx, "get_reservation", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Reservation{}, fmt.Errorf("%s: %w", op, ErrReservationNotFound)
+		}
+		return models.Reservation{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+
+	return res, nil
+}
+
+func (pos *Postgres) CancelReservation(ctx context.Context, id int) (_ models.Reservation, err error) {
+	const op = "storage.postgres.CancelReservation"
+	defer observe(ctx, op, time.Now(), &err)
+
+	tag, err := pos.pool.Exec(ctx, "cancel_reservation", id)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: exec failed: %w", op, pgError(err))
+	}
+
+	res, err := pos.GetReservation(ctx, id)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, ErrReservationNotCancellable)
+	}
+	reservationsCancelled.Inc()
+
+	return res, nil
+}
+
+// ConfirmReservation turns a hold that has not expired, or a pending
+// reservation, into a confirmed booking. The update waits for a sweeper that
+// holds the row, and then finds the hold expired.
+func (pos *Postgres) ConfirmReservation(ctx context.Context, id int) (_ models.Reservation, err error) {
+	const op = "storage.postgres.ConfirmReservation"
+	defer observe(ctx, op, time.Now(), &err)
+
+	var was models.ReservationStatus
+	err = pos.pool.QueryRow(ctx, "confirm_reservation", id).Scan(&was)
+	confirmed := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return models.Reservation{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+	// Pending reservations were counted when they were created, holds are
+	// counted once they are confirmed.
+	if was == models.ReservationHeld {
+		reservationsCreated.Inc()
+	}
+
+	res, err := pos.GetReservation(ctx, id)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !confirmed {
+		if res.Status == models.ReservationHeld || res.Status == models.ReservationExpired {
+			return models.Reservation{}, fmt.Errorf("%s: %w", op, ErrHoldExpired)
+		}
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, ErrReservationNotConfirmable)
+	}
+
+	return res, nil
+}
+
+// ExpireHolds skips the holds another instance is expiring or confirming,
+// so concurrent sweepers never wait on each other or mark a hold twice.
+func (pos *Postgres) ExpireHolds(ctx context.Context, limit int) (_ int, err error) {
+	const op = "storage.postgres.ExpireHolds"
+	defer observe(ctx, op, time.Now(), &err)
+
+	tag, err := pos.pool.Exec(ctx, "expire_holds", limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: exec failed: %w", op, pgError(err))
+	}
+
+	expired := int(tag.RowsAffected())
+	holdsExpired.Add(float64(expired))
+
+	return expired, nil
+}
+
+// CheckInReservation assigns hotelRoomId, the room assigned at booking time or
+// the free room of the type with the lowest number, and marks the reservation
+// checked in. reservations_no_overlap rejects a room that is already taken.
+func (pos *Postgres) CheckInReservation(ctx context.Context, id int, hotelRoomId int) (_ models.Reservation, err error) {
+	const op = "storage.postgres.CheckInReservation"
+	defer observe(ctx, op, time.Now(), &err)
+
+	tx, err := pos.pool.Begin(ctx)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: begin failed: %w", op, pgError(err))
+	}
+	defer tx.Rollback(ctx)
+
+	res, err := scanReservation(tx.QueryRow(ctx, "lock_reservation", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Reservation{}, fmt.Errorf("%s: %w", op, ErrReservationNotFound)
+		}
+		return models.Reservation{}, fmt.Errorf("%s: lock failed: %w", op, pgError(err))
+	}
+
+	if res.Status != models.ReservationPending && res.Status != models.ReservationConfirmed {
+		return models.Reservation{}, fmt.Errorf("%s: %w", op, ErrReservationNotCheckable)
+	}
+
+	if hotelRoomId == 0 {
+		hotelRoomId = res.HotelRoomId
+	}
+	if hotelRoomId == 0 {
+		err = tx.QueryRow(ctx, "free_hotel_room", res.RoomTypeId, res.CheckIn.Time, res.CheckOut.Time).Scan(&hotelRoomId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.Reservation{}, fmt.Errorf("%s: %w", op, ErrRoomTypeSoldOut)
+			}
+			return models.Reservation{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+		}
+	}
+
+	_, err = tx.Exec(ctx, "check_in_reservation", hotelRoomId, id)
+	if err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: exec failed: %w", op, pos.checkInErr(ctx, hotelRoomId, err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Reservation{}, fmt.Errorf("%s: commit failed: %w", op, pgError(err))
+	}
+
+	return pos.GetReservation(ctx, id)
+}
+
+// checkInErr translates constraint violations raised by assigning a room. The
+// composite room key fails both for a missing room and for a room of another
+// type, so the room is looked up to tell them apart.
+func (pos *Postgres) checkInErr(ctx context.Context, hotelRoomId int, err error) error {
+	switch pgErrCode(err) {
+	case pgExclusionViolation:
+		return ErrRoomAlreadyBooked
+	case pgForeignKeyViolation:
+		var exists bool
+		if pos.pool.QueryRow(ctx, "hotel_room_exists", hotelRoomId).Scan(&exists) == nil && exists {
+			return ErrRoomTypeMismatch
+		}
+		return ErrHotelRoomNotFound
+	}
+	return pgError(err)
+}
+
+func scanReservation(row pgx.Row) (models.Reservation, error) {
+	var (
+		res      models.Reservation
+		checkIn  time.Time
+		checkOut time.Time
+	)
+
+	err := row.Scan(&res.Id, &res.RoomTypeId, &res.HotelRoomId, &res.VisitorId, &checkIn, &checkOut, &res.Guests, &res.Status, &res.CreatedAt,
+		&res.RatePlanId, &res.Currency, (*int64)(&res.Total), &res.ExpiresAt)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	res.CheckIn = models.NewDate(checkIn)
+	res.CheckOut = models.NewDate(checkOut)
+
+	return res, nil
+}
+
+func scanReservations(op string, rows pgx.Rows) ([]models.Reservation, error) {
+	defer rows.Close()
+
+	reservations := []models.Reservation{}
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan failed: %w", op, pgError(err))
+		}
+
+		reservations = append(reservations, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows failed: %w", op, pgError(err))
+	}
+
+	return reservations, nil
+}
+
+// reservationWriteErr translates constraint violations raised by reservation writes.
+func reservationWriteErr(err error) error {
+	switch pgErrCode(err) {
+	case pgExclusionViolation:
+		return ErrRoomAlreadyBooked
+	case pgCheckViolation:
+		return ErrReservationInvalid
+	case pgForeignKeyViolation:
+		if pgConstraint(err) == "reservations_visitor_id_fkey" {
+			return ErrVisitorNotFound
+		}
+		return ErrRoomTypeNotFound
+	}
+	return pgError(err)
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const restrictionColumns = `room_type_id, day, min_stay, max_stay, closed_to_arrival, closed_to_departure, stop_sell`
+
+// maxRestrictionDays bounds the dates one update or listing may span.
+const maxRestrictionDays = 366
+
+// Rules of the field errors CheckStay reports.
+const (
+	RuleMinStay           = "min_stay"
+	RuleMaxStay           = "max_stay"
+	RuleClosedToArrival   = "closed_to_arrival"
+	RuleClosedToDeparture = "closed_to_departure"
+	RuleStopSell          = "stop_sell"
+)
+
+func (pos *Postgres) ListRestrictions(ctx context.Context, roomTypeId int, from models.Date, to models.Date) (_ []models.StayRestriction, err error) {
+	const op = "storage.postgres.ListRestrictions"
+	defer observe(ctx, op, time.Now(), &err)
+
+	if err := CheckRestrictionRange(from, to); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	restrictions, err := queryRestrictions(ctx, pos.pool, []int{roomTypeId}, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// An empty list may also mean there is no such room type.
+	if len(restrictions) == 0 {
+		if _, err := pos.GetRoomType(ctx, roomTypeId); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return restrictions, nil
+}
+
+// UpdateRestrictions holds the room type row like CreateReservation, so a
+// booking never checks restrictions that are halfway through an update.
+func (pos *Postgres) UpdateRestrictions(ctx context.Context, roomTypeId int, u models.RestrictionUpdate) (_ []models.StayRestriction, err error) {
+	const op = "storage.postgres.UpdateRestrictions"
+	defer observe(ctx, op, time.Now(), &err)
+
+	if err := CheckRestrictionRange(u.From, u.To); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := pos.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin failed: %w", op, pgError(err))
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = scanRoomType(tx.QueryRow(ctx, "lock_room_type", roomTypeId)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrRoomTypeNotFound)
+		}
+		return nil, fmt.Errorf("%s: lock failed: %w", op, pgError(err))
+	}
+
+	var days []time.Time
+	for _, d := range u.Dates() {
+		days = append(days, d.Time)
+	}
+
+	_, err = tx.Exec(ctx, "set_stay_restrictions", roomTypeId, days,
+		u.MinStay, u.MaxStay, u.ClosedToArrival, u.ClosedToDeparture, u.StopSell)
+	if err != nil {
+		if pgErrCode(err) == pgCheckViolation {
+			return nil, fmt.Errorf("%s: %w", op, ErrRestrictionInvalid)
+		}
+		return nil, fmt.Errorf("%s: exec failed: %w", op, pgError(err))
+	}
+
+	if _, err = tx.Exec(ctx, "clear_stay_restrictions", roomTypeId, days); err != nil {
+		return nil, fmt.Errorf("%s: delete failed: %w", op, pgError(err))
+	}
+
+	restrictions, err := queryRestrictions(ctx, tx, []int{roomTypeId}, u.From, u.To)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit failed: %w", op, pgError(err))
+	}
+
+	return restrictions, nil
+}
+
+// CheckRestrictionRange validates the dates of a restriction update or
+// listing.
+func CheckRestrictionRange(from, to models.Date) error {
+	if from.IsZero() || to.IsZero() || to.Before(from.Time) || to.Sub(from.Time) >= maxRestrictionDays*24*time.Hour {
+		return ErrRestrictionRangeInvalid
+	}
+	return nil
+}
+
+// CheckStay returns ErrStayRestricted when restrictions forbid a stay from
+// checkIn to checkOut, listing every broken restriction as a field error.
+// restrictions are those of the room type from checkIn to checkOut; dates
+// without a restriction may be missing. Both repositories check stays
+// through it.
+func CheckStay(restrictions []models.StayRestriction, checkIn, checkOut models.Date) error {
+	nights := int(checkOut.Sub(checkIn.Time) / (24 * time.Hour))
+
+	var fields []FieldError
+	for _, r := range restrictions {
+		day := r.Date.String()
+
+		switch {
+		case r.Date.Equal(checkIn.Time):
+			if r.ClosedToArrival {
+				fields = append(fields, FieldError{Field: "check_in", Rule: RuleClosedToArrival, Param: day,
+					Message: "arrivals are closed on " + day})
+			}
+			if r.MinStay != 0 && nights < r.MinStay {
+				fields = append(fields, FieldError{Field: "check_out", Rule: RuleMinStay, Param: strconv.Itoa(r.MinStay),
+					Message: fmt.Sprintf("stays arriving on %s must be at least %d nights", day, r.MinStay)})
+			}
+			if r.MaxStay != 0 && nights > r.MaxStay {
+				fields = append(fields, FieldError{Field: "check_out", Rule: RuleMaxStay, Param: strconv.Itoa(r.MaxStay),
+					Message: fmt.Sprintf("stays arriving on %s must be at most %d nights", day, r.MaxStay)})
+			}
+		case r.Date.Equal(checkOut.Time):
+			if r.ClosedToDeparture {
+				fields = append(fields, FieldError{Field: "check_out", Rule: RuleClosedToDeparture, Param: day,
+					Message: "departures are closed on " + day})
+			}
+			// The night of the departure day is not part of the stay.
+			continue
+		}
+
+		if r.StopSell && !r.Date.Before(checkIn.Time) && r.Date.Before(checkOut.Time) {
+			fields = append(fields, FieldError{Field: "check_in", Rule: RuleStopSell, Param: day,
+				Message: "the night of " + day + " is not for sale"})
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		msgs = append(msgs, f.Message)
+	}
+
+	return &Error{Kind: ErrStayRestricted, Msg: ErrStayRestricted.Msg + ": " + strings.Join(msgs, "; "), Fields: fields}
+}
+
+// queryRestrictions returns the restrictions of the room types from from to
+// to, ordered by room type and date.
+func queryRestrictions(ctx context.Context, db querier, roomTypeIds []int, from, to models.Date) ([]models.StayRestriction, error) {
+	rows, err := db.Query(ctx, "list_stay_restrictions", roomTypeIds, from.Time, to.Time)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", pgError(err))
+	}
+
+	restrictions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.StayRestriction, error) {
+		var (
+			r   models.StayRestriction
+			day time.Time
+		)
+		err := row.Scan(&r.RoomTypeId, &day, &r.MinStay, &r.MaxStay, &r.ClosedToArrival, &r.ClosedToDeparture, &r.StopSell)
+		r.Date = models.NewDate(day)
+		return r, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", pgError(err))
+	}
+
+	return restrictions, nil
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCheckStay(t *testing.T) {
+	date := func(s string) models.Date {
+		d, err := models.ParseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	restrictions := []models.StayRestriction{
+		{Date: date("2030-07-05"), MinStay: 3, ClosedToDeparture: true},
+		{Date: date("2030-07-06"), MaxStay: 2},
+		{Date: date("2030-07-08"), ClosedToArrival: true},
+		{Date: date("2030-07-09"), StopSell: true},
+	}
+
+	tests := []struct {
+		name     string
+		checkIn  string
+		checkOut string
+		want     []string
+	}{
+		{"long enough", "2030-07-05", "2030-07-08", nil},
+		{"too short", "2030-07-05", "2030-07-07", []string{RuleMinStay}},
+		{"too long", "2030-07-06", "2030-07-10", []string{RuleMaxStay, RuleStopSell}},
+		{"closed to arrival", "2030-07-08", "2030-07-09", []string{RuleClosedToArrival}},
+		{"closed to departure", "2030-07-03", "2030-07-05", []string{RuleClosedToDeparture}},
+		{"stop-sell night", "2030-07-07", "2030-07-10", []string{RuleStopSell}},
+		{"leaving on a stop-sell day", "2030-07-07", "2030-07-09", nil},
+		{"only passing through", "2030-07-04", "2030-07-07", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStay(restrictions, date(tt.checkIn), date(tt.checkOut))
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("CheckStay = %v, want nil", err)
+				}
+				return
+			}
+
+			var domainErr *Error
+			if !errors.Is(err, ErrStayRestricted) || !errors.Is(err, ErrConflict) || !errors.As(err, &domainErr) {
+				t.Fatalf("CheckStay = %v, want ErrStayRestricted", err)
+			}
+
+			var rules []string
+			for _, f := range domainErr.Fields {
+				rules = append(rules, f.Rule)
+			}
+			if !slices.Equal(rules, tt.want) {
+				t.Fatalf("CheckStay rules = %v, want %v (%v)", rules, tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"bookings/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const roomTypeColumns = `id, hotel_id, name, description, max_adults, max_children, bed_configuration,
+	base_occupancy, size_sqm`
+
+func (pos *Postgres) CreateRoomType(ctx context.Context, rt models.RoomType) (_ models.RoomType, err error) {
+	const op = "storage.postgres.CreateRoomType"
+	defer observe(ctx, op, time.Now(), &err)
+
+	var id int
+	err = pos.pool.QueryRow(ctx, "create_room_type", rt.HotelId, rt.Name, rt.Description, rt.MaxAdults, rt.MaxChildren,
+		rt.BedConfiguration, rt.BaseOccupancy, rt.SizeSqm).Scan(&id)
+	if err != nil {
+		return models.RoomType{}, fmt.Errorf("%s: exec failed: %w", op, roomTypeWriteErr(err))
+	}
+
+	return pos.GetRoomType(ctx, id)
+}
+
+// RoomTypeFilter selects room types for ListRoomTypes. Zero fields do not
+// filter; GuestsMin keeps types that can host at least that many guests.
+// Amenities keeps types offering every listed code, either themselves or
+// through their hotel.
+type RoomTypeFilter struct {
+	PageRequest
+	HotelId   int      `form:"hotel_id" binding:"omitempty,gt=0"`
+	GuestsMin int      `form:"guests_min" binding:"omitempty,gt=0"`
+	Amenities []string `form:"amenities"`
+}
+
+// RoomTypeSortFields are the fields room types can be sorted by.
+var RoomTypeSortFields = map[string]SortField[models.RoomType]{
+	"id":             {Column: "id", Value: func(rt models.RoomType) any { return rt.Id }},
+	"name":           {Column: "name", Value: func(rt models.RoomType) any { return rt.Name }},
+	"base_occupancy": {Column: "base_occupancy", Value: func(rt models.RoomType) any { return rt.BaseOccupancy }},
+	"size_sqm":       {Column: "size_sqm", Value: func(rt models.RoomType) any { return rt.SizeSqm }},
+}
+
+func (pos *Postgres) ListRoomTypes(ctx context.Context, filter RoomTypeFilter) (_ models.Page[models.RoomType], err error) {
+	const op = "storage.postgres.ListRoomTypes"
+	defer observe(ctx, op, time.Now(), &err)
+
+	k, err := NewKeyset(filter.PageRequest, RoomTypeSortFields, "id")
+	if err != nil {
+		return models.Page[models.RoomType]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var q listQuery
+	if filter.HotelId != 0 {
+		q.filter("hotel_id = %s", filter.HotelId)
+	}
+	if filter.GuestsMin != 0 {
+		q.filter("max_adults + max_children >= %s", filter.GuestsMin)
+	}
+	if codes := AmenityCodes(filter.Amenities); len(codes) > 0 {
+		q.filter(offersAmenities("room_types.hotel_id", "room_types.id", "%s"), codes)
+	}
+
+	query := build(&q, "SELECT "+roomTypeColumns+" FROM room_types", k)
+
+	rows, err := pos.pool.Query(ctx, query, q.args...)
+	if err != nil {
+		return models.Page[models.RoomType]{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+	defer rows.Close()
+
+	roomTypes := []models.RoomType{}
+	for rows.Next() {
+		rt, err := scanRoomType(rows)
+		if err != nil {
+			return models.Page[models.RoomType]{}, fmt.Errorf("%s: scan failed: %w", op, pgError(err))
+		}
+		roomTypes = append(roomTypes, rt)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[models.RoomType]{}, fmt.Errorf("%s: rows failed: %w", op, pgError(err))
+	}
+
+	return k.Page(roomTypes), nil
+}
+
+func (pos *Postgres) GetRoomType(ctx context.Context, id int) (_ models.RoomType, err error) {
+	const op = "storage.postgres.GetRoomType"
+	defer observe(ctx, op, time.Now(), &err)
+
+	rt, err := scanRoomType(pos.pool.QueryRow(ctx, "get_room_type", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.RoomType{}, fmt.Errorf("%s: %w", op, ErrRoomTypeNotFound)
+		}
+		return models.RoomType{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
+
+	return rt, nil
+}
+
+func (pos *Postgres) UpdateRoomType(ctx context.Context, id int, rt models.RoomType) (_ models.RoomType, err error) {
+	const op = "storage.postgres.UpdateRoomType"
+	defer observe(ctx, op, time.Now(), &err)
+
+	tag, err := pos.pool.Exec(ctx, "update_room_type", rt.Name, rt.Description, rt.MaxAdults, rt.MaxChildren,
+		rt.BedConfiguration, rt.BaseOccupancy, rt.SizeSqm, id)
+	if err != nil {
+		return models.RoomType{}, fmt.Errorf("%s: update failed: %w", op, roomTypeWriteErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.RoomType{}, fmt.Errorf("%s: %w", op, ErrRoomTypeNotFound)
+	}
+
+	return pos.GetRoomType(ctx, id)
+}
+
+func (pos *Postgres) DeleteRoomType(ctx context.Context, id int) (err error) {
+	const op = "storage.postgres.DeleteRoomType"
+	defer observe(ctx, op, time.Now(), &err)
+
+	tag, err := pos.pool.Exec(ctx, "delete_room_type", id)
+	if err != nil {
+		if pgErrCode(err) == pgForeignKeyViolation {
+			switch pgConstraint(err) {
+			case "reservations_room_type_id_fkey", "reservations_rate_plan_fkey":
+				return fmt.Errorf("%s: %w", op, ErrRoomTypeHasReservations)
+			}
+			return fmt.Errorf("%s: %w", op, ErrRoomTypeHasRooms)
+		}
+		return fmt.Errorf("%s: delete failed: %w", op, pgError(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrRoomTypeNotFound)
+	}
+
+	return nil
+}
+
+func scanRoomType(row pgx.Row) (models.RoomType, error) {
+	var rt models.RoomType
+	err := row.Scan(&rt.Id, &rt.HotelId, &rt.Name, &rt.Description, &rt.MaxAdults, &rt.MaxChildren,
+		&rt.BedConfiguration, &rt.BaseOccupancy, &rt.SizeSqm)
+	return rt, err
+}
+
+// roomTypeWriteErr translates constraint violations raised by room type writes.
+func roomTypeWriteErr(err error) error {
+	switch pgErrCode(err) {
+	case pgForeignKeyViolation:
+		return ErrHotelNotFound
+	case pgCheckViolation:
+		return ErrRoomTypeInvalid
+	case pgUniqueViolation:
+		return ErrRoomTypeExists
+	}
+	return pgError(err)
+}
@@ -0,0 +1,1300 @@
+// Package storagetest holds the behavioural contract every storage.Repository
+// implementation has to satisfy.
+package storagetest
+
+import (
+	"bookings/internal/models"
+	"bookings/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// RunRepositoryContract runs the contract against fresh repositories returned
+// by newRepo. newRepo is called once per subtest and must return an empty store.
+func RunRepositoryContract(t *testing.T, newRepo func(t *testing.T) storage.Repository) {
+	t.Run("Hotels", func(t *testing.T) { testHotels(t, newRepo(t)) })
+	t.Run("RoomTypes", func(t *testing.T) { testRoomTypes(t, newRepo(t)) })
+	t.Run("RatePlans", func(t *testing.T) { testRatePlans(t, newRepo(t)) })
+	t.Run("Pricing", func(t *testing.T) { testPricing(t, newRepo(t)) })
+	t.Run("Restrictions", func(t *testing.T) { testRestrictions(t, newRepo(t)) })
+	t.Run("Amenities", func(t *testing.T) { testAmenities(t, newRepo(t)) })
+	t.Run("HotelRooms", func(t *testing.T) { testHotelRooms(t, newRepo(t)) })
+	t.Run("Visitors", func(t *testing.T) { testVisitors(t, newRepo(t)) })
+	t.Run("Reservations", func(t *testing.T) { testReservations(t, newRepo(t)) })
+	t.Run("Holds", func(t *testing.T) { testHolds(t, newRepo(t)) })
+	t.Run("CheckIn", func(t *testing.T) { testCheckIn(t, newRepo(t)) })
+	t.Run("Availability", func(t *testing.T) { testAvailability(t, newRepo(t)) })
+	t.Run("Pagination", func(t *testing.T) { testPagination(t, newRepo(t)) })
+}
+
+func testHotels(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Germany", "Berlin", "Adlon", 5)
+
+	got := must[models.Hotel](t)(repo.GetHotel(ctx, hotel.Id))
+	if got != hotel {
+		t.Fatalf("GetHotel = %+v, want %+v", got, hotel)
+	}
+
+	_, err := repo.CreateHotel(ctx, "Germany", "Munich", "Adlon", 4)
+	expectErr(t, err, storage.ErrHotelExists)
+
+	for _, stars := range []int{0, 6} {
+		_, err = repo.CreateHotel(ctx, "Germany", "Munich", "Stars", stars)
+		expectErr(t, err, storage.ErrHotelInvalid)
+	}
+
+	_, err = repo.GetHotel(ctx, hotel.Id+100)
+	expectErr(t, err, storage.ErrHotelNotFound)
+
+	updated := must[models.Hotel](t)(repo.UpdateHotel(ctx, hotel.Id, "Germany", "Potsdam", "Adlon Kempinski", 4))
+	want := models.Hotel{Id: hotel.Id, Country: "Germany", City: "Potsdam", HotelName: "Adlon Kempinski", Stars: 4}
+	if updated != want {
+		t.Fatalf("UpdateHotel = %+v, want %+v", updated, want)
+	}
+
+	other := MustCreateHotel(t, repo, "France", "Paris", "Ritz", 5)
+
+	_, err = repo.UpdateHotel(ctx, other.Id, "France", "Paris", "Adlon Kempinski", 5)
+	expectErr(t, err, storage.ErrHotelExists)
+
+	_, err = repo.UpdateHotel(ctx, other.Id, "France", "Paris", "Ritz", 9)
+	expectErr(t, err, storage.ErrHotelInvalid)
+
+	_, err = repo.UpdateHotel(ctx, other.Id+100, "France", "Paris", "Nowhere", 3)
+	expectErr(t, err, storage.ErrHotelNotFound)
+
+	all := must[models.Page[models.Hotel]](t)(repo.ListHotels(ctx, storage.HotelFilter{})).Items
+	if len(all) != 2 || all[0].Id != hotel.Id || all[1].Id != other.Id {
+		t.Fatalf("ListHotels = %+v, want hotels %d and %d", all, hotel.Id, other.Id)
+	}
+
+	// Rooms and room types are never deleted along with their hotel.
+	roomType := MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := MustCreateHotelRoom(t, repo, roomType)
+
+	expectErr(t, repo.DeleteHotel(ctx, hotel.Id), storage.ErrHotelHasRooms)
+	expectErr(t, repo.DeleteHotel(ctx, hotel.Id+100), storage.ErrHotelNotFound)
+
+	if err := repo.DeleteHotelRoom(ctx, room.Id); err != nil {
+		t.Fatalf("DeleteHotelRoom: %v", err)
+	}
+	expectErr(t, repo.DeleteHotel(ctx, hotel.Id), storage.ErrHotelHasRooms)
+
+	if err := repo.DeleteRoomType(ctx, roomType.Id); err != nil {
+		t.Fatalf("DeleteRoomType: %v", err)
+	}
+	if err := repo.DeleteHotel(ctx, hotel.Id); err != nil {
+		t.Fatalf("DeleteHotel: %v", err)
+	}
+
+	_, err = repo.GetHotel(ctx, hotel.Id)
+	expectErr(t, err, storage.ErrHotelNotFound)
+}
+
+func testRoomTypes(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Portugal", "Lisbon", "Pestana", 5)
+	other := MustCreateHotel(t, repo, "Portugal", "Porto", "Infante Sagres", 5)
+
+	double := models.RoomType{
+		HotelId:          hotel.Id,
+		Name:             "Double",
+		Description:      "Street side",
+		MaxAdults:        2,
+		MaxChildren:      1,
+		BedConfiguration: "1 queen",
+		BaseOccupancy:    2,
+		SizeSqm:          22,
+	}
+	created := must[models.RoomType](t)(repo.CreateRoomType(ctx, double))
+	double.Id = created.Id
+	if created != double {
+		t.Fatalf("CreateRoomType = %+v, want %+v", created, double)
+	}
+
+	got := must[models.RoomType](t)(repo.GetRoomType(ctx, double.Id))
+	if got != double {
+		t.Fatalf("GetRoomType = %+v, want %+v", got, double)
+	}
+
+	_, err := repo.GetRoomType(ctx, double.Id+100)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	_, err = repo.CreateRoomType(ctx, models.RoomType{HotelId: hotel.Id, Name: "Double", MaxAdults: 2, BaseOccupancy: 2})
+	expectErr(t, err, storage.ErrRoomTypeExists)
+
+	// The name only has to be unique within the hotel.
+	must[models.RoomType](t)(repo.CreateRoomType(ctx, models.RoomType{HotelId: other.Id, Name: "Double", MaxAdults: 2, BaseOccupancy: 2}))
+
+	_, err = repo.CreateRoomType(ctx, models.RoomType{HotelId: other.Id + 100, Name: "Single", MaxAdults: 1, BaseOccupancy: 1})
+	expectErr(t, err, storage.ErrHotelNotFound)
+
+	for _, invalid := range []models.RoomType{
+		{HotelId: hotel.Id, Name: "Nobody", MaxAdults: 0, MaxChildren: 2, BaseOccupancy: 1},
+		{HotelId: hotel.Id, Name: "Crowded", MaxAdults: 2, BaseOccupancy: 3},
+		{HotelId: hotel.Id, Name: "Empty", MaxAdults: 2, BaseOccupancy: 0},
+		{HotelId: hotel.Id, Name: "Tiny", MaxAdults: 2, BaseOccupancy: 2, SizeSqm: -1},
+	} {
+		_, err = repo.CreateRoomType(ctx, invalid)
+		expectErr(t, err, storage.ErrRoomTypeInvalid)
+	}
+
+	suite := must[models.RoomType](t)(repo.CreateRoomType(ctx, models.RoomType{HotelId: hotel.Id, Name: "Suite", MaxAdults: 4, BaseOccupancy: 2}))
+
+	byHotel := must[models.Page[models.RoomType]](t)(repo.ListRoomTypes(ctx, storage.RoomTypeFilter{HotelId: hotel.Id})).Items
+	if len(byHotel) != 2 || byHotel[0].Id != double.Id || byHotel[1].Id != suite.Id {
+		t.Fatalf("ListRoomTypes(hotel) = %+v", byHotel)
+	}
+
+	large := must[models.Page[models.RoomType]](t)(repo.ListRoomTypes(ctx, storage.RoomTypeFilter{GuestsMin: 4})).Items
+	if len(large) != 1 || large[0].Id != suite.Id {
+		t.Fatalf("ListRoomTypes(guests_min=4) = %+v", large)
+	}
+
+	// HotelId is ignored: a type stays with its hotel.
+	double.Name, double.MaxChildren, double.HotelId = "Double Deluxe", 0, other.Id
+	updated := must[models.RoomType](t)(repo.UpdateRoomType(ctx, double.Id, double))
+	double.HotelId = hotel.Id
+	if updated != double {
+		t.Fatalf("UpdateRoomType = %+v, want %+v", updated, double)
+	}
+
+	_, err = repo.UpdateRoomType(ctx, double.Id, models.RoomType{Name: "Suite", MaxAdults: 2, BaseOccupancy: 2})
+	expectErr(t, err, storage.ErrRoomTypeExists)
+
+	_, err = repo.UpdateRoomType(ctx, double.Id, models.RoomType{Name: "Double", MaxAdults: 1, BaseOccupancy: 2})
+	expectErr(t, err, storage.ErrRoomTypeInvalid)
+
+	_, err = repo.UpdateRoomType(ctx, double.Id+100, models.RoomType{Name: "Gone", MaxAdults: 1, BaseOccupancy: 1})
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	room := MustCreateHotelRoom(t, repo, suite)
+	expectErr(t, repo.DeleteRoomType(ctx, suite.Id), storage.ErrRoomTypeHasRooms)
+
+	// Reservations keep their type even when no room is assigned yet.
+	guest := MustCreateVisitor(t, repo, MustCreateHotelRoom(t, repo, MustCreateRoomType(t, repo, other.Id, 2)))
+	MustCreateReservation(t, repo, suite.Id, guest.Id, "2030-07-01", "2030-07-03")
+	if err := repo.DeleteHotelRoom(ctx, room.Id); err != nil {
+		t.Fatalf("DeleteHotelRoom: %v", err)
+	}
+	expectErr(t, repo.DeleteRoomType(ctx, suite.Id), storage.ErrRoomTypeHasReservations)
+
+	expectErr(t, repo.DeleteRoomType(ctx, double.Id+100), storage.ErrRoomTypeNotFound)
+
+	if err := repo.DeleteRoomType(ctx, double.Id); err != nil {
+		t.Fatalf("DeleteRoomType: %v", err)
+	}
+
+	_, err = repo.GetRoomType(ctx, double.Id)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+}
+
+func testRatePlans(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Italy", "Rome", "Hassler", 5)
+	roomType := MustCreateRoomType(t, repo, hotel.Id, 3)
+
+	plan := models.RatePlan{
+		RoomTypeId:      roomType.Id,
+		Name:            "Flexible",
+		Currency:        "EUR",
+		BasePrice:       12000,
+		WeekendPrice:    15000,
+		ExtraGuestPrice: 2500,
+		Seasons: []models.Season{
+			{From: Date(t, "2030-12-20"), To: Date(t, "2031-01-02"), Price: 25000},
+			{From: Date(t, "2030-07-01"), To: Date(t, "2030-08-31"), Price: 18000, WeekendPrice: 20000},
+		},
+		StayDiscounts: []models.StayDiscount{{MinNights: 7, Percent: 15}, {MinNights: 3, Percent: 5}},
+	}
+
+	created := must[models.RatePlan](t)(repo.CreateRatePlan(ctx, plan))
+	if created.Id == 0 || created.Name != "Flexible" || created.BasePrice != 12000 || len(created.Seasons) != 2 || len(created.StayDiscounts) != 2 {
+		t.Fatalf("CreateRatePlan = %+v", created)
+	}
+	// Seasons come back by start date, discounts by length.
+	if created.Seasons[0].From != Date(t, "2030-07-01") || created.StayDiscounts[0].MinNights != 3 {
+		t.Fatalf("CreateRatePlan order = %+v", created)
+	}
+
+	got := must[models.RatePlan](t)(repo.GetRatePlan(ctx, created.Id))
+	if !slices.Equal(got.Seasons, created.Seasons) || !slices.Equal(got.StayDiscounts, created.StayDiscounts) || got.ExtraGuestPrice != 2500 {
+		t.Fatalf("GetRatePlan = %+v, want %+v", got, created)
+	}
+
+	_, err := repo.GetRatePlan(ctx, created.Id+100)
+	expectErr(t, err, storage.ErrRatePlanNotFound)
+
+	_, err = repo.CreateRatePlan(ctx, plan)
+	expectErr(t, err, storage.ErrRatePlanExists)
+
+	invalid := map[string]struct {
+		edit func(rp *models.RatePlan)
+		want error
+	}{
+		"currency":          {func(rp *models.RatePlan) { rp.Currency = "eur" }, storage.ErrRatePlanInvalid},
+		"no decimals":       {func(rp *models.RatePlan) { rp.Currency = "JPY" }, storage.ErrRatePlanCurrency},
+		"three decimals":    {func(rp *models.RatePlan) { rp.Currency = "KWD" }, storage.ErrRatePlanCurrency},
+		"base price":        {func(rp *models.RatePlan) { rp.BasePrice = 0 }, storage.ErrRatePlanInvalid},
+		"negative weekend":  {func(rp *models.RatePlan) { rp.WeekendPrice = -1 }, storage.ErrRatePlanInvalid},
+		"price too high":    {func(rp *models.RatePlan) { rp.ExtraGuestPrice = models.MaxPrice + 1 }, storage.ErrRatePlanInvalid},
+		"season too high":   {func(rp *models.RatePlan) { rp.Seasons[0].Price = models.MaxPrice + 1 }, storage.ErrRatePlanSeasonInvalid},
+		"room type":         {func(rp *models.RatePlan) { rp.RoomTypeId += 100 }, storage.ErrRoomTypeNotFound},
+		"reversed season":   {func(rp *models.RatePlan) { rp.Seasons[0].To = Date(t, "2030-12-01") }, storage.ErrRatePlanSeasonInvalid},
+		"season price":      {func(rp *models.RatePlan) { rp.Seasons[1].Price = 0 }, storage.ErrRatePlanSeasonInvalid},
+		"overlapping":       {func(rp *models.RatePlan) { rp.Seasons[1].To = Date(t, "2030-12-20") }, storage.ErrRatePlanSeasonInvalid},
+		"short discount":    {func(rp *models.RatePlan) { rp.StayDiscounts[0].MinNights = 1 }, storage.ErrRatePlanDiscountInvalid},
+		"discount percent":  {func(rp *models.RatePlan) { rp.StayDiscounts[0].Percent = 101 }, storage.ErrRatePlanDiscountInvalid},
+		"repeated discount": {func(rp *models.RatePlan) { rp.StayDiscounts[1].MinNights = 7 }, storage.ErrRatePlanDiscountInvalid},
+	}
+	for name, tt := range invalid {
+		rp := plan
+		rp.Name = "Invalid " + name
+		rp.Seasons = slices.Clone(plan.Seasons)
+		rp.StayDiscounts = slices.Clone(plan.StayDiscounts)
+		tt.edit(&rp)
+
+		_, err = repo.CreateRatePlan(ctx, rp)
+		if !errors.Is(err, tt.want) {
+			t.Fatalf("CreateRatePlan with invalid %s: error = %v, want %v", name, err, tt.want)
+		}
+	}
+
+	if plans := must[[]models.RatePlan](t)(repo.ListRatePlans(ctx, roomType.Id)); len(plans) != 1 || plans[0].Id != created.Id {
+		t.Fatalf("ListRatePlans = %+v, want only plan %d", plans, created.Id)
+	}
+
+	nonRefundable := must[models.RatePlan](t)(repo.CreateRatePlan(ctx, models.RatePlan{
+		RoomTypeId: roomType.Id, Name: "Non-refundable", Currency: "EUR", BasePrice: 10000,
+	}))
+	if nonRefundable.Seasons == nil || len(nonRefundable.Seasons) != 0 || nonRefundable.StayDiscounts == nil {
+		t.Fatalf("CreateRatePlan without seasons = %#v, want empty lists", nonRefundable)
+	}
+
+	updated := must[models.RatePlan](t)(repo.UpdateRatePlan(ctx, created.Id, models.RatePlan{
+		RoomTypeId:    roomType.Id + 100,
+		Name:          "Flexible",
+		Currency:      "CHF",
+		BasePrice:     13000,
+		StayDiscounts: []models.StayDiscount{{MinNights: 4, Percent: 10}},
+	}))
+	if updated.RoomTypeId != roomType.Id || updated.Currency != "CHF" || len(updated.Seasons) != 0 || len(updated.StayDiscounts) != 1 {
+		t.Fatalf("UpdateRatePlan = %+v", updated)
+	}
+
+	_, err = repo.UpdateRatePlan(ctx, created.Id, models.RatePlan{Name: "Non-refundable", Currency: "EUR", BasePrice: 100})
+	expectErr(t, err, storage.ErrRatePlanExists)
+
+	_, err = repo.UpdateRatePlan(ctx, created.Id, models.RatePlan{Name: "Flexible", Currency: "JPY", BasePrice: 100})
+	expectErr(t, err, storage.ErrRatePlanCurrency)
+
+	_, err = repo.UpdateRatePlan(ctx, created.Id+100, models.RatePlan{Name: "Gone", Currency: "EUR", BasePrice: 100})
+	expectErr(t, err, storage.ErrRatePlanNotFound)
+
+	_, err = repo.ListRatePlans(ctx, roomType.Id+100)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	vis := MustCreateVisitor(t, repo, MustCreateHotelRoom(t, repo, roomType))
+	booked := must[models.Reservation](t)(repo.CreateReservation(ctx, roomType.Id, nonRefundable.Id, vis.Id,
+		Date(t, "2030-05-06"), Date(t, "2030-05-08"), 1))
+
+	expectErr(t, repo.DeleteRatePlan(ctx, nonRefundable.Id), storage.ErrRatePlanHasReservations)
+	expectErr(t, repo.DeleteRatePlan(ctx, created.Id+100), storage.ErrRatePlanNotFound)
+	if err := repo.DeleteRatePlan(ctx, created.Id); err != nil {
+		t.Fatalf("DeleteRatePlan: %v", err)
+	}
+
+	if _, err := repo.CancelReservation(ctx, booked.Id); err != nil {
+		t.Fatalf("CancelReservation: %v", err)
+	}
+	expectErr(t, repo.DeleteRatePlan(ctx, nonRefundable.Id), storage.ErrRatePlanHasReservations)
+}
+
+// testPricing checks that quotes, availability results and reservations
+// agree on the price of a stay.
+func testPricing(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Italy", "Rome", "Hassler", 5)
+	roomType := must[models.RoomType](t)(repo.CreateRoomType(ctx, models.RoomType{
+		HotelId: hotel.Id, Name: "Family", MaxAdults: 2, MaxChildren: 2, BaseOccupancy: 2,
+	}))
+	vis := MustCreateVisitor(t, repo, MustCreateHotelRoom(t, repo, roomType))
+	unpriced := MustCreateRoomType(t, repo, hotel.Id, 2)
+	MustCreateHotelRoom(t, repo, unpriced)
+
+	_, err := repo.QuoteStay(ctx, roomType.Id, 0, Date(t, "2030-07-01"), Date(t, "2030-07-03"), 2)
+	expectErr(t, err, storage.ErrRoomTypeNotPriced)
+
+	flexible := must[models.RatePlan](t)(repo.CreateRatePlan(ctx, models.RatePlan{
+		RoomTypeId: roomType.Id, Name: "Flexible", Currency: "EUR", BasePrice: 10000, WeekendPrice: 13000,
+		ExtraGuestPrice: 1550, StayDiscounts: []models.StayDiscount{{MinNights: 3, Percent: 10}},
+	}))
+	saver := must[models.RatePlan](t)(repo.CreateRatePlan(ctx, models.RatePlan{
+		RoomTypeId: roomType.Id, Name: "Saver", Currency: "EUR", BasePrice: 9000, ExtraGuestPrice: 5000,
+	}))
+
+	// Thursday to Sunday for three guests: 115.50 + 145.50 + 145.50, less
+	// 10 percent, beats 3 x 140.00 under the saver plan.
+	checkIn, checkOut := Date(t, "2030-07-04"), Date(t, "2030-07-07")
+
+	quote := must[models.Quote](t)(repo.QuoteStay(ctx, roomType.Id, 0, checkIn, checkOut, 3))
+	if quote.RatePlanId != flexible.Id || quote.Currency != "EUR" || len(quote.Nights) != 3 ||
+		quote.Subtotal != 40650 || quote.Discount != 4065 || quote.Total != 36585 {
+		t.Fatalf("QuoteStay = %+v", quote)
+	}
+
+	saverQuote := must[models.Quote](t)(repo.QuoteStay(ctx, roomType.Id, saver.Id, checkIn, checkOut, 3))
+	if saverQuote.RatePlanId != saver.Id || saverQuote.Total != 42000 {
+		t.Fatalf("QuoteStay(saver) = %+v", saverQuote)
+	}
+
+	_, err = repo.QuoteStay(ctx, unpriced.Id, saver.Id, checkIn, checkOut, 1)
+	expectErr(t, err, storage.ErrRatePlanNotOffered)
+	_, err = repo.QuoteStay(ctx, roomType.Id, 0, checkIn, checkOut, 5)
+	expectErr(t, err, storage.ErrReservationTooManyGuests)
+	_, err = repo.QuoteStay(ctx, roomType.Id, 0, checkOut, checkIn, 1)
+	expectErr(t, err, storage.ErrReservationInvalid)
+	_, err = repo.QuoteStay(ctx, roomType.Id, 0, Date(t, "2026-01-01"), Date(t, "9999-12-31"), 1)
+	expectErr(t, err, storage.ErrReservationInvalid)
+	_, err = repo.QuoteStay(ctx, roomType.Id+100, 0, checkIn, checkOut, 1)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	found := must[[]models.HotelAvailability](t)(repo.SearchAvailability(ctx, storage.AvailabilityFilter{
+		CheckIn: checkIn, CheckOut: checkOut, Guests: 2, StarsMin: 1,
+	}))
+	if len(found) != 1 || len(found[0].RoomTypes) != 2 {
+		t.Fatalf("SearchAvailability = %+v, want both room types", found)
+	}
+	for _, rt := range found[0].RoomTypes {
+		switch {
+		case rt.Id == unpriced.Id && rt.Quote != nil:
+			t.Fatalf("SearchAvailability quoted the unpriced type: %+v", rt.Quote)
+		// Without the third guest's supplement the saver plan is cheaper.
+		case rt.Id == roomType.Id && (rt.Quote == nil || rt.Quote.RatePlanId != saver.Id || rt.Quote.Total != 27000):
+			t.Fatalf("SearchAvailability quote = %+v, want plan %d at 270.00", rt.Quote, saver.Id)
+		}
+	}
+
+	res := must[models.Reservation](t)(repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, checkIn, checkOut, 3))
+	if res.RatePlanId != quote.RatePlanId || res.Currency != quote.Currency || res.Total != quote.Total {
+		t.Fatalf("CreateReservation = %+v, want priced like %+v", res, quote)
+	}
+	if got := must[models.Reservation](t)(repo.GetReservation(ctx, res.Id)); got != res {
+		t.Fatalf("GetReservation = %+v, want %+v", got, res)
+	}
+
+	_, err = repo.CreateReservation(ctx, unpriced.Id, saver.Id, vis.Id, checkIn, checkOut, 1)
+	expectErr(t, err, storage.ErrRatePlanNotOffered)
+
+	free := must[models.Reservation](t)(repo.CreateReservation(ctx, unpriced.Id, 0, vis.Id, checkIn, checkOut, 1))
+	if free.RatePlanId != 0 || free.Total != 0 || free.Currency != "" {
+		t.Fatalf("CreateReservation without rate plans = %+v", free)
+	}
+}
+
+func testRestrictions(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Greece", "Athens", "Grande Bretagne", 5)
+	roomType := MustCreateRoomType(t, repo, hotel.Id, 2)
+	vis := MustCreateVisitor(t, repo, MustCreateHotelRoom(t, repo, roomType))
+	july := func(day string) models.Date { return Date(t, "2030-07-"+day) }
+	intp := func(n int) *int { return &n }
+	boolp := func(b bool) *bool { return &b }
+
+	// Minimum three nights for arrivals on every weekend in July.
+	weekends := must[[]models.StayRestriction](t)(repo.UpdateRestrictions(ctx, roomType.Id, models.RestrictionUpdate{
+		From: july("01"), To: july("31"), Weekdays: []string{"fri", "sat"}, MinStay: intp(3),
+	}))
+	if len(weekends) != 8 || weekends[0].Date != july("05") || weekends[7].Date != july("27") || weekends[0].MinStay != 3 {
+		t.Fatalf("UpdateRestrictions = %+v, want the 8 Fridays and Saturdays of July", weekends)
+	}
+
+	// Stop-selling Saturday 13th keeps its minimum stay.
+	must[[]models.StayRestriction](t)(repo.UpdateRestrictions(ctx, roomType.Id, models.RestrictionUpdate{
+		From: july("13"), To: july("14"), StopSell: boolp(true), ClosedToDeparture: boolp(true),
+	}))
+	got := must[[]models.StayRestriction](t)(repo.ListRestrictions(ctx, roomType.Id, july("12"), july("14")))
+	want := []models.StayRestriction{
+		{RoomTypeId: roomType.Id, Date: july("12"), MinStay: 3},
+		{RoomTypeId: roomType.Id, Date: july("13"), MinStay: 3, ClosedToDeparture: true, StopSell: true},
+		{RoomTypeId: roomType.Id, Date: july("14"), ClosedToDeparture: true, StopSell: true},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ListRestrictions = %+v, want %+v", got, want)
+	}
+
+	// Lifting everything on the 14th removes the date.
+	cleared := must[[]models.StayRestriction](t)(repo.UpdateRestrictions(ctx, roomType.Id, models.RestrictionUpdate{
+		From: july("14"), To: july("14"), StopSell: boolp(false), ClosedToDeparture: boolp(false),
+	}))
+	if len(cleared) != 0 {
+		t.Fatalf("UpdateRestrictions lifting everything = %+v, want none", cleared)
+	}
+
+	_, err := repo.UpdateRestrictions(ctx, roomType.Id, models.RestrictionUpdate{From: july("05"), To: july("05"), MaxStay: intp(2)})
+	expectErr(t, err, storage.ErrRestrictionInvalid)
+	_, err = repo.UpdateRestrictions(ctx, roomType.Id, models.RestrictionUpdate{From: july("05"), To: july("05"), MinStay: intp(-1)})
+	expectErr(t, err, storage.ErrRestrictionInvalid)
+	_, err = repo.UpdateRestrictions(ctx, roomType.Id, models.RestrictionUpdate{From: july("05"), To: july("04"), MinStay: intp(2)})
+	expectErr(t, err, storage.ErrRestrictionRangeInvalid)
+	_, err = repo.UpdateRestrictions(ctx, roomType.Id, models.RestrictionUpdate{From: july("01"), To: Date(t, "2031-07-02"), MinStay: intp(2)})
+	expectErr(t, err, storage.ErrRestrictionRangeInvalid)
+	_, err = repo.UpdateRestrictions(ctx, roomType.Id+100, models.RestrictionUpdate{From: july("05"), To: july("05"), MinStay: intp(2)})
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+	_, err = repo.ListRestrictions(ctx, roomType.Id+100, july("01"), july("31"))
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+	_, err = repo.ListRestrictions(ctx, roomType.Id, models.Date{}, july("31"))
+	expectErr(t, err, storage.ErrRestrictionRangeInvalid)
+
+	// The failed updates changed nothing.
+	if got := must[[]models.StayRestriction](t)(repo.ListRestrictions(ctx, roomType.Id, july("05"), july("05"))); len(got) != 1 || got[0].MaxStay != 0 {
+		t.Fatalf("ListRestrictions after failed updates = %+v", got)
+	}
+
+	search := func(checkIn, checkOut string) []models.HotelAvailability {
+		return must[[]models.HotelAvailability](t)(repo.SearchAvailability(ctx, storage.AvailabilityFilter{
+			CheckIn: july(checkIn), CheckOut: july(checkOut), Guests: 1, StarsMin: 1,
+		}))
+	}
+
+	rejected := map[string][2]string{
+		"short weekend":  {"05", "07"},
+		"stop-sell":      {"11", "14"},
+		"departure day":  {"10", "13"},
+		"weekend at all": {"06", "08"},
+	}
+	for name, stay := range rejected {
+		_, err = repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, july(stay[0]), july(stay[1]), 1)
+		if !errors.Is(err, storage.ErrStayRestricted) {
+			t.Fatalf("CreateReservation for the %s stay: error = %v, want %v", name, err, storage.ErrStayRestricted)
+		}
+		if found := search(stay[0], stay[1]); len(found) != 0 {
+			t.Fatalf("SearchAvailability for the %s stay = %+v, want nothing", name, found)
+		}
+	}
+
+	var domainErr *storage.Error
+	_, err = repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, july("12"), july("14"), 1)
+	if !errors.As(err, &domainErr) || len(domainErr.Fields) != 2 ||
+		domainErr.Fields[0].Rule != storage.RuleMinStay || domainErr.Fields[1].Rule != storage.RuleStopSell {
+		t.Fatalf("CreateReservation breaking two restrictions: error = %#v, want min_stay and stop_sell", err)
+	}
+
+	if found := search("19", "22"); len(found) != 1 {
+		t.Fatalf("SearchAvailability for a long weekend = %+v, want the hotel", found)
+	}
+	must[models.Reservation](t)(repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, july("19"), july("22"), 1))
+	must[models.Reservation](t)(repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, july("01"), july("03"), 1))
+}
+
+func testAmenities(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	wifi := MustCreateAmenity(t, repo, "wifi", "connectivity")
+	pool := MustCreateAmenity(t, repo, "pool", "wellness")
+	spa := MustCreateAmenity(t, repo, "spa", "wellness")
+	meals := must[models.Amenity](t)(repo.CreateAmenity(ctx, models.Amenity{
+		Code:     "meals",
+		Category: "food",
+		Labels:   map[string]string{"en": "Meals included", "de": "Mahlzeiten inklusive"},
+	}))
+
+	got := must[models.Amenity](t)(repo.GetAmenity(ctx, "meals"))
+	if got.Category != "food" || got.Labels["de"] != "Mahlzeiten inklusive" {
+		t.Fatalf("GetAmenity = %+v, want %+v", got, meals)
+	}
+	if got = must[models.Amenity](t)(repo.GetAmenity(ctx, "wifi")); got.Labels == nil || len(got.Labels) != 0 {
+		t.Fatalf("GetAmenity labels = %#v, want an empty map", got.Labels)
+	}
+
+	_, err := repo.CreateAmenity(ctx, wifi)
+	expectErr(t, err, storage.ErrAmenityExists)
+
+	for _, a := range []models.Amenity{{Code: "Wi-Fi", Category: "connectivity"}, {Code: "bar", Category: ""}} {
+		_, err = repo.CreateAmenity(ctx, a)
+		expectErr(t, err, storage.ErrAmenityInvalid)
+	}
+
+	_, err = repo.GetAmenity(ctx, "sauna")
+	expectErr(t, err, storage.ErrAmenityNotFound)
+
+	updated := must[models.Amenity](t)(repo.UpdateAmenity(ctx, "wifi", models.Amenity{Category: "internet", Labels: map[string]string{"en": "Wi-Fi"}}))
+	if updated.Code != "wifi" || updated.Category != "internet" || updated.Labels["en"] != "Wi-Fi" {
+		t.Fatalf("UpdateAmenity = %+v", updated)
+	}
+
+	_, err = repo.UpdateAmenity(ctx, "sauna", models.Amenity{Category: "wellness"})
+	expectErr(t, err, storage.ErrAmenityNotFound)
+
+	_, err = repo.UpdateAmenity(ctx, "wifi", models.Amenity{})
+	expectErr(t, err, storage.ErrAmenityInvalid)
+
+	all := must[[]models.Amenity](t)(repo.ListAmenities(ctx, storage.AmenityFilter{}))
+	if codes := amenityCodes(all); !slices.Equal(codes, []string{"meals", "wifi", "pool", "spa"}) {
+		t.Fatalf("ListAmenities = %v, want ordered by category and code", codes)
+	}
+	wellness := must[[]models.Amenity](t)(repo.ListAmenities(ctx, storage.AmenityFilter{Category: "wellness"}))
+	if codes := amenityCodes(wellness); !slices.Equal(codes, []string{"pool", "spa"}) {
+		t.Fatalf("ListAmenities(wellness) = %v", codes)
+	}
+
+	hotel := MustCreateHotel(t, repo, "Spain", "Madrid", "Palace", 5)
+	plain := MustCreateHotel(t, repo, "Spain", "Madrid", "Hostal", 2)
+	family := MustCreateRoomType(t, repo, hotel.Id, 4)
+	double := MustCreateRoomType(t, repo, hotel.Id, 2)
+	familyRoom := MustCreateHotelRoom(t, repo, family)
+	doubleRoom := MustCreateHotelRoom(t, repo, double)
+
+	if offered := must[[]models.Amenity](t)(repo.ListHotelAmenities(ctx, hotel.Id)); len(offered) != 0 {
+		t.Fatalf("ListHotelAmenities before set = %+v", offered)
+	}
+
+	offered := must[[]models.Amenity](t)(repo.SetHotelAmenities(ctx, hotel.Id, []string{"wifi", "pool", "wifi"}))
+	if codes := amenityCodes(offered); !slices.Equal(codes, []string{"wifi", "pool"}) {
+		t.Fatalf("SetHotelAmenities = %v", codes)
+	}
+	must[[]models.Amenity](t)(repo.SetRoomTypeAmenities(ctx, family.Id, []string{"meals"}))
+
+	_, err = repo.SetHotelAmenities(ctx, hotel.Id, []string{"wifi", "sauna"})
+	expectErr(t, err, storage.ErrAmenityNotFound)
+	if codes := amenityCodes(must[[]models.Amenity](t)(repo.ListHotelAmenities(ctx, hotel.Id))); len(codes) != 2 {
+		t.Fatalf("ListHotelAmenities after failed set = %v, want unchanged", codes)
+	}
+
+	_, err = repo.SetHotelAmenities(ctx, hotel.Id+100, []string{"wifi"})
+	expectErr(t, err, storage.ErrHotelNotFound)
+	_, err = repo.ListHotelAmenities(ctx, hotel.Id+100)
+	expectErr(t, err, storage.ErrHotelNotFound)
+	_, err = repo.SetRoomTypeAmenities(ctx, family.Id+100, nil)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+	_, err = repo.ListRoomTypeAmenities(ctx, family.Id+100)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	expectErr(t, repo.DeleteAmenity(ctx, pool.Code), storage.ErrAmenityInUse)
+	expectErr(t, repo.DeleteAmenity(ctx, "meals"), storage.ErrAmenityInUse)
+	expectErr(t, repo.DeleteAmenity(ctx, "sauna"), storage.ErrAmenityNotFound)
+	if err := repo.DeleteAmenity(ctx, spa.Code); err != nil {
+		t.Fatalf("DeleteAmenity: %v", err)
+	}
+
+	hotels := must[models.Page[models.Hotel]](t)(repo.ListHotels(ctx, storage.HotelFilter{Amenities: []string{"wifi,pool"}})).Items
+	if len(hotels) != 1 || hotels[0].Id != hotel.Id {
+		t.Fatalf("ListHotels(wifi,pool) = %+v, want hotel %d", hotels, hotel.Id)
+	}
+	hotels = must[models.Page[models.Hotel]](t)(repo.ListHotels(ctx, storage.HotelFilter{Amenities: []string{"wifi", "meals"}})).Items
+	if len(hotels) != 0 {
+		t.Fatalf("ListHotels(wifi,meals) = %+v, want none", hotels)
+	}
+
+	// Room types offer their own amenities and those of their hotel.
+	roomTypes := must[models.Page[models.RoomType]](t)(repo.ListRoomTypes(ctx, storage.RoomTypeFilter{Amenities: []string{"wifi", "meals"}})).Items
+	if len(roomTypes) != 1 || roomTypes[0].Id != family.Id {
+		t.Fatalf("ListRoomTypes(wifi,meals) = %+v, want type %d", roomTypes, family.Id)
+	}
+
+	found := must[[]models.HotelAvailability](t)(repo.SearchAvailability(ctx, storage.AvailabilityFilter{
+		CheckIn:   Date(t, "2030-07-01"),
+		CheckOut:  Date(t, "2030-07-03"),
+		Guests:    1,
+		StarsMin:  1,
+		Amenities: []string{"pool", "meals"},
+	}))
+	if len(found) != 1 || len(found[0].RoomTypes) != 1 || found[0].RoomTypes[0].Id != family.Id {
+		t.Fatalf("SearchAvailability(pool,meals) = %+v, want only type %d", found, family.Id)
+	}
+
+	// Deleting the owners removes their links, which frees the amenities.
+	for _, room := range []models.HotelRoom{familyRoom, doubleRoom} {
+		if err := repo.DeleteHotelRoom(ctx, room.Id); err != nil {
+			t.Fatalf("DeleteHotelRoom: %v", err)
+		}
+	}
+	for _, rt := range []models.RoomType{family, double} {
+		if err := repo.DeleteRoomType(ctx, rt.Id); err != nil {
+			t.Fatalf("DeleteRoomType: %v", err)
+		}
+	}
+	if err := repo.DeleteHotel(ctx, hotel.Id); err != nil {
+		t.Fatalf("DeleteHotel: %v", err)
+	}
+	for _, code := range []string{"pool", "meals"} {
+		if err := repo.DeleteAmenity(ctx, code); err != nil {
+			t.Fatalf("DeleteAmenity(%s) after hotel delete: %v", code, err)
+		}
+	}
+
+	if offered := must[[]models.Amenity](t)(repo.SetHotelAmenities(ctx, plain.Id, nil)); len(offered) != 0 {
+		t.Fatalf("SetHotelAmenities(nil) = %+v", offered)
+	}
+}
+
+func testHotelRooms(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Italy", "Rome", "Hassler", 5)
+	other := MustCreateHotel(t, repo, "Italy", "Milan", "Armani", 5)
+	roomType := MustCreateRoomType(t, repo, hotel.Id, 3)
+	otherType := MustCreateRoomType(t, repo, other.Id, 2)
+
+	room := must[models.HotelRoom](t)(repo.CreateHotelRoom(ctx, hotel.Id, roomType.Id, 101, 1))
+	want := models.HotelRoom{Id: room.Id, HotelId: hotel.Id, RoomTypeId: roomType.Id, RoomNumber: 101, Floor: 1}
+	if room != want {
+		t.Fatalf("CreateHotelRoom = %+v, want %+v", room, want)
+	}
+
+	_, err := repo.CreateHotelRoom(ctx, other.Id+100, roomType.Id, 1, 0)
+	expectErr(t, err, storage.ErrHotelNotFound)
+
+	_, err = repo.CreateHotelRoom(ctx, hotel.Id, roomType.Id+100, 1, 0)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	// The room type has to belong to the room's hotel.
+	_, err = repo.CreateHotelRoom(ctx, hotel.Id, otherType.Id, 1, 0)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	_, err = repo.CreateHotelRoom(ctx, hotel.Id, roomType.Id, 0, 0)
+	expectErr(t, err, storage.ErrHotelRoomInvalid)
+
+	_, err = repo.CreateHotelRoom(ctx, hotel.Id, roomType.Id, room.RoomNumber, 2)
+	expectErr(t, err, storage.ErrHotelRoomExists)
+
+	got := must[models.HotelRoom](t)(repo.GetHotelRoom(ctx, room.Id))
+	if got != room {
+		t.Fatalf("GetHotelRoom = %+v, want %+v", got, room)
+	}
+
+	_, err = repo.GetHotelRoom(ctx, room.Id+100)
+	expectErr(t, err, storage.ErrHotelRoomNotFound)
+
+	second := MustCreateHotelRoom(t, repo, otherType)
+
+	byHotel := must[models.Page[models.HotelRoom]](t)(repo.ListHotelRooms(ctx, storage.HotelRoomFilter{HotelId: hotel.Id})).Items
+	if len(byHotel) != 1 || byHotel[0] != room {
+		t.Fatalf("ListHotelRooms(hotel) = %+v, want [%+v]", byHotel, room)
+	}
+
+	byType := must[models.Page[models.HotelRoom]](t)(repo.ListHotelRooms(ctx, storage.HotelRoomFilter{RoomTypeId: otherType.Id})).Items
+	if len(byType) != 1 || byType[0] != second {
+		t.Fatalf("ListHotelRooms(room type) = %+v, want [%+v]", byType, second)
+	}
+
+	large := must[models.Page[models.HotelRoom]](t)(repo.ListHotelRooms(ctx, storage.HotelRoomFilter{GuestsMin: 3})).Items
+	if len(large) != 1 || large[0] != room {
+		t.Fatalf("ListHotelRooms(guests_min=3) = %+v, want [%+v]", large, room)
+	}
+
+	all := must[models.Page[models.HotelRoom]](t)(repo.ListHotelRooms(ctx, storage.HotelRoomFilter{})).Items
+	if len(all) != 2 || all[0].Id != room.Id || all[1].Id != second.Id {
+		t.Fatalf("ListHotelRooms = %+v", all)
+	}
+
+	updated := must[models.HotelRoom](t)(repo.UpdateHotelRoom(ctx, room.Id, other.Id, otherType.Id, 202, 2))
+	want = models.HotelRoom{Id: room.Id, HotelId: other.Id, RoomTypeId: otherType.Id, RoomNumber: 202, Floor: 2}
+	if updated != want {
+		t.Fatalf("UpdateHotelRoom = %+v, want %+v", updated, want)
+	}
+
+	_, err = repo.UpdateHotelRoom(ctx, room.Id, other.Id+100, otherType.Id, 202, 2)
+	expectErr(t, err, storage.ErrHotelNotFound)
+
+	_, err = repo.UpdateHotelRoom(ctx, room.Id, other.Id, roomType.Id, 202, 2)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	_, err = repo.UpdateHotelRoom(ctx, room.Id+100, other.Id, otherType.Id, 202, 2)
+	expectErr(t, err, storage.ErrHotelRoomNotFound)
+
+	_, err = repo.UpdateHotelRoom(ctx, room.Id, other.Id, otherType.Id, second.RoomNumber, 2)
+	expectErr(t, err, storage.ErrHotelRoomExists)
+
+	// Moving a room to another hotel moves its visitors along.
+	guest := MustCreateVisitor(t, repo, updated)
+	must[models.HotelRoom](t)(repo.UpdateHotelRoom(ctx, room.Id, hotel.Id, roomType.Id, 101, 1))
+
+	moved := must[models.Visitor](t)(repo.GetVisitor(ctx, guest.Id))
+	if moved.HotelId != hotel.Id {
+		t.Fatalf("visitor hotel = %d after moving the room, want %d", moved.HotelId, hotel.Id)
+	}
+	if err := repo.DeleteVisitor(ctx, guest.Id); err != nil {
+		t.Fatalf("DeleteVisitor: %v", err)
+	}
+
+	MustCreateVisitor(t, repo, second)
+
+	expectErr(t, repo.DeleteHotelRoom(ctx, second.Id), storage.ErrHotelRoomHasVisitors)
+	expectErr(t, repo.DeleteHotelRoom(ctx, second.Id+100), storage.ErrHotelRoomNotFound)
+
+	if err := repo.DeleteHotelRoom(ctx, room.Id); err != nil {
+		t.Fatalf("DeleteHotelRoom: %v", err)
+	}
+}
+
+func testVisitors(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Spain", "Madrid", "Palace", 5)
+	roomType := MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := MustCreateHotelRoom(t, repo, roomType)
+	otherRoom := MustCreateHotelRoom(t, repo, roomType)
+
+	vis := MustCreateVisitor(t, repo, room)
+
+	for _, age := range []int{17, 101} {
+		_, err := repo.CreateVisitor(ctx, hotel.Id, room.Id, "Young", "Guest", age)
+		expectErr(t, err, storage.ErrVisitorInvalidAge)
+	}
+
+	_, err := repo.CreateVisitor(ctx, hotel.Id, otherRoom.Id+100, "Lost", "Guest", 30)
+	expectErr(t, err, storage.ErrHotelRoomNotFound)
+
+	elsewhere := MustCreateHotel(t, repo, "Spain", "Seville", "Alfonso XIII", 5)
+	_, err = repo.CreateVisitor(ctx, elsewhere.Id, room.Id, "Lost", "Guest", 30)
+	expectErr(t, err, storage.ErrVisitorHotelMismatch)
+
+	got := must[models.Visitor](t)(repo.GetVisitor(ctx, vis.Id))
+	if got != vis {
+		t.Fatalf("GetVisitor = %+v, want %+v", got, vis)
+	}
+
+	_, err = repo.GetVisitor(ctx, vis.Id+100)
+	expectErr(t, err, storage.ErrVisitorNotFound)
+
+	other := must[models.Visitor](t)(repo.CreateVisitor(ctx, hotel.Id, otherRoom.Id, "Ana", "Lopez", 40))
+
+	updated := must[models.Visitor](t)(repo.UpdateVisitor(ctx, vis.Id, hotel.Id, otherRoom.Id, "Juan", "Perez", 50))
+	want := models.Visitor{Id: vis.Id, HotelId: hotel.Id, HotelRoom: otherRoom.Id, FirstName: "Juan", LastName: "Perez", Age: 50}
+	if updated != want {
+		t.Fatalf("UpdateVisitor = %+v, want %+v", updated, want)
+	}
+
+	// The update must touch only the addressed row.
+	unchanged := must[models.Visitor](t)(repo.GetVisitor(ctx, other.Id))
+	if unchanged != other {
+		t.Fatalf("UpdateVisitor changed another visitor: %+v, want %+v", unchanged, other)
+	}
+
+	_, err = repo.UpdateVisitor(ctx, vis.Id, hotel.Id, otherRoom.Id, "Juan", "Perez", 10)
+	expectErr(t, err, storage.ErrVisitorInvalidAge)
+
+	_, err = repo.UpdateVisitor(ctx, vis.Id+100, hotel.Id, otherRoom.Id, "Juan", "Perez", 50)
+	expectErr(t, err, storage.ErrVisitorNotFound)
+
+	byRoom := must[models.Page[models.Visitor]](t)(repo.ListVisitors(ctx, storage.VisitorFilter{HotelRoomId: otherRoom.Id})).Items
+	if len(byRoom) != 2 || byRoom[0].Id != vis.Id || byRoom[1].Id != other.Id {
+		t.Fatalf("ListVisitors(room) = %+v", byRoom)
+	}
+
+	byHotel := must[models.Page[models.Visitor]](t)(repo.ListVisitors(ctx, storage.VisitorFilter{HotelId: hotel.Id})).Items
+	if len(byHotel) != 2 {
+		t.Fatalf("ListVisitors(hotel) = %+v", byHotel)
+	}
+
+	all := must[models.Page[models.Visitor]](t)(repo.ListVisitors(ctx, storage.VisitorFilter{})).Items
+	if len(all) != 2 {
+		t.Fatalf("ListVisitors = %+v", all)
+	}
+
+	expectErr(t, repo.DeleteVisitor(ctx, vis.Id+100), storage.ErrVisitorNotFound)
+
+	if err := repo.DeleteVisitor(ctx, vis.Id); err != nil {
+		t.Fatalf("DeleteVisitor: %v", err)
+	}
+
+	_, err = repo.GetVisitor(ctx, vis.Id)
+	expectErr(t, err, storage.ErrVisitorNotFound)
+}
+
+func testReservations(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Austria", "Vienna", "Sacher", 5)
+	roomType := MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := MustCreateHotelRoom(t, repo, roomType)
+	vis := MustCreateVisitor(t, repo, room)
+
+	res := MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-07-10", "2030-07-14")
+	if res.Status != models.ReservationPending || res.Guests != 1 || res.RoomTypeId != roomType.Id || res.HotelRoomId != 0 {
+		t.Fatalf("CreateReservation = %+v", res)
+	}
+
+	// The type has a single room: overlapping stays sell it out, back-to-back
+	// stays are fine.
+	_, err := repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, Date(t, "2030-07-13"), Date(t, "2030-07-15"), 1)
+	expectErr(t, err, storage.ErrRoomTypeSoldOut)
+
+	next := MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-07-14", "2030-07-16")
+
+	// A second room of the type takes a second overlapping stay.
+	MustCreateHotelRoom(t, repo, roomType)
+	MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-07-13", "2030-07-15")
+
+	_, err = repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, Date(t, "2030-07-12"), Date(t, "2030-07-14"), 1)
+	expectErr(t, err, storage.ErrRoomTypeSoldOut)
+
+	_, err = repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, Date(t, "2030-08-02"), Date(t, "2030-08-01"), 1)
+	expectErr(t, err, storage.ErrReservationInvalid)
+
+	_, err = repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, Date(t, "2030-08-01"), Date(t, "2031-08-02"), 1)
+	expectErr(t, err, storage.ErrReservationInvalid)
+
+	_, err = repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, Date(t, "2030-08-01"), Date(t, "2030-08-02"), 0)
+	expectErr(t, err, storage.ErrReservationInvalid)
+
+	_, err = repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, Date(t, "2030-08-01"), Date(t, "2030-08-02"), 3)
+	expectErr(t, err, storage.ErrReservationTooManyGuests)
+
+	_, err = repo.CreateReservation(ctx, roomType.Id+100, 0, vis.Id, Date(t, "2030-08-01"), Date(t, "2030-08-02"), 1)
+	expectErr(t, err, storage.ErrRoomTypeNotFound)
+
+	_, err = repo.CreateReservation(ctx, roomType.Id, 0, vis.Id+100, Date(t, "2030-08-01"), Date(t, "2030-08-02"), 1)
+	expectErr(t, err, storage.ErrVisitorNotFound)
+
+	got := must[models.Reservation](t)(repo.GetReservation(ctx, res.Id))
+	if got.Id != res.Id || got.RoomTypeId != res.RoomTypeId || got.CheckIn != res.CheckIn || got.CheckOut != res.CheckOut {
+		t.Fatalf("GetReservation = %+v, want %+v", got, res)
+	}
+
+	_, err = repo.GetReservation(ctx, next.Id+100)
+	expectErr(t, err, storage.ErrReservationNotFound)
+
+	byType := must[models.Page[models.Reservation]](t)(repo.ListReservations(ctx, storage.ReservationFilter{RoomTypeId: roomType.Id})).Items
+	if len(byType) != 3 || byType[0].Id != res.Id || byType[2].Id != next.Id {
+		t.Fatalf("ListReservations(room type) = %+v", byType)
+	}
+
+	byHotel := must[models.Page[models.Reservation]](t)(repo.ListReservations(ctx, storage.ReservationFilter{HotelId: hotel.Id})).Items
+	if len(byHotel) != 3 {
+		t.Fatalf("ListReservations(hotel) = %+v", byHotel)
+	}
+
+	all := must[models.Page[models.Reservation]](t)(repo.ListReservations(ctx, storage.ReservationFilter{})).Items
+	if len(all) != 3 {
+		t.Fatalf("ListReservations = %+v", all)
+	}
+
+	cancelled := must[models.Reservation](t)(repo.CancelReservation(ctx, res.Id))
+	if cancelled.Status != models.ReservationCancelled {
+		t.Fatalf("CancelReservation status = %q", cancelled.Status)
+	}
+
+	_, err = repo.CancelReservation(ctx, res.Id)
+	expectErr(t, err, storage.ErrReservationNotCancellable)
+
+	_, err = repo.CancelReservation(ctx, next.Id+100)
+	expectErr(t, err, storage.ErrReservationNotFound)
+
+	// A cancelled reservation frees its dates.
+	MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-07-11", "2030-07-13")
+
+	expectErr(t, repo.DeleteVisitor(ctx, vis.Id), storage.ErrVisitorHasReservations)
+}
+
+func testHolds(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Czechia", "Prague", "Kings Court", 4)
+	roomType := MustCreateRoomType(t, repo, hotel.Id, 2)
+	room := MustCreateHotelRoom(t, repo, roomType)
+	vis := MustCreateVisitor(t, repo, room)
+
+	checkIn, checkOut := Date(t, "2030-07-10"), Date(t, "2030-07-12")
+
+	hold := must[models.Reservation](t)(repo.HoldReservation(ctx, roomType.Id, 0, vis.Id, checkIn, checkOut, 1, time.Hour))
+	if hold.Status != models.ReservationHeld || hold.ExpiresAt == nil || !hold.ExpiresAt.After(time.Now()) {
+		t.Fatalf("HoldReservation = %+v", hold)
+	}
+
+	// A hold counts against availability like a booking.
+	_, err := repo.CreateReservation(ctx, roomType.Id, 0, vis.Id, checkIn, checkOut, 1)
+	expectErr(t, err, storage.ErrRoomTypeSoldOut)
+
+	_, err = repo.HoldReservation(ctx, roomType.Id, 0, vis.Id, checkIn, checkOut, 1, time.Hour)
+	expectErr(t, err, storage.ErrRoomTypeSoldOut)
+
+	confirmed := must[models.Reservation](t)(repo.ConfirmReservation(ctx, hold.Id))
+	if confirmed.Status != models.ReservationConfirmed || confirmed.ExpiresAt != nil {
+		t.Fatalf("ConfirmReservation = %+v", confirmed)
+	}
+
+	_, err = repo.ConfirmReservation(ctx, hold.Id)
+	expectErr(t, err, storage.ErrReservationNotConfirmable)
+
+	// An expired hold stops blocking the room type even before it is swept.
+	later, laterOut := Date(t, "2030-08-10"), Date(t, "2030-08-12")
+	short := must[models.Reservation](t)(repo.HoldReservation(ctx, roomType.Id, 0, vis.Id, later, laterOut, 1, 50*time.Millisecond))
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = repo.ConfirmReservation(ctx, short.Id)
+	expectErr(t, err, storage.ErrHoldExpired)
+
+	booked := MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-08-10", "2030-08-12")
+
+	if n := must[int](t)(repo.ExpireHolds(ctx, 10)); n != 1 {
+		t.Fatalf("ExpireHolds = %d, want 1", n)
+	}
+	if n := must[int](t)(repo.ExpireHolds(ctx, 10)); n != 0 {
+		t.Fatalf("ExpireHolds again = %d, want 0", n)
+	}
+
+	expired := must[models.Reservation](t)(repo.GetReservation(ctx, short.Id))
+	if expired.Status != models.ReservationExpired {
+		t.Fatalf("expired hold status = %q", expired.Status)
+	}
+
+	_, err = repo.ConfirmReservation(ctx, short.Id)
+	expectErr(t, err, storage.ErrHoldExpired)
+
+	_, err = repo.CancelReservation(ctx, short.Id)
+	expectErr(t, err, storage.ErrReservationNotCancellable)
+
+	byStatus := must[models.Page[models.Reservation]](t)(repo.ListReservations(ctx, storage.ReservationFilter{Status: models.ReservationExpired})).Items
+	if len(byStatus) != 1 || byStatus[0].Id != short.Id {
+		t.Fatalf("ListReservations(expired) = %+v", byStatus)
+	}
+
+	// Cancelling a hold releases it at once.
+	must[models.Reservation](t)(repo.CancelReservation(ctx, booked.Id))
+	released := must[models.Reservation](t)(repo.HoldReservation(ctx, roomType.Id, 0, vis.Id, later, laterOut, 1, time.Hour))
+	if cancelled := must[models.Reservation](t)(repo.CancelReservation(ctx, released.Id)); cancelled.Status != models.ReservationCancelled {
+		t.Fatalf("CancelReservation(hold) status = %q", cancelled.Status)
+	}
+	MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-08-10", "2030-08-12")
+
+	_, err = repo.ConfirmReservation(ctx, released.Id)
+	expectErr(t, err, storage.ErrReservationNotConfirmable)
+
+	_, err = repo.HoldReservation(ctx, roomType.Id, 0, vis.Id, Date(t, "2030-09-01"), Date(t, "2030-09-02"), 1, 0)
+	expectErr(t, err, storage.ErrHoldInvalid)
+
+	_, err = repo.ConfirmReservation(ctx, released.Id+100)
+	expectErr(t, err, storage.ErrReservationNotFound)
+}
+
+func testCheckIn(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotel := MustCreateHotel(t, repo, "Czechia", "Prague", "Augustine", 5)
+	roomType := MustCreateRoomType(t, repo, hotel.Id, 2)
+	otherType := MustCreateRoomType(t, repo, hotel.Id, 2)
+	high := must[models.HotelRoom](t)(repo.CreateHotelRoom(ctx, hotel.Id, roomType.Id, 302, 3))
+	low := must[models.HotelRoom](t)(repo.CreateHotelRoom(ctx, hotel.Id, roomType.Id, 301, 3))
+	elsewhere := MustCreateHotelRoom(t, repo, otherType)
+	vis := MustCreateVisitor(t, repo, low)
+
+	first := MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-07-10", "2030-07-14")
+	second := MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-07-12", "2030-07-13")
+
+	// Without a room the free room with the lowest number is assigned.
+	checkedIn := must[models.Reservation](t)(repo.CheckInReservation(ctx, first.Id, 0))
+	if checkedIn.Status != models.ReservationCheckedIn || checkedIn.HotelRoomId != low.Id {
+		t.Fatalf("CheckInReservation = %+v, want checked in to room %d", checkedIn, low.Id)
+	}
+
+	_, err := repo.CheckInReservation(ctx, first.Id, 0)
+	expectErr(t, err, storage.ErrReservationNotCheckable)
+
+	_, err = repo.CheckInReservation(ctx, second.Id, low.Id)
+	expectErr(t, err, storage.ErrRoomAlreadyBooked)
+
+	_, err = repo.CheckInReservation(ctx, second.Id, elsewhere.Id)
+	expectErr(t, err, storage.ErrRoomTypeMismatch)
+
+	_, err = repo.CheckInReservation(ctx, second.Id, high.Id+100)
+	expectErr(t, err, storage.ErrHotelRoomNotFound)
+
+	_, err = repo.CheckInReservation(ctx, second.Id+100, 0)
+	expectErr(t, err, storage.ErrReservationNotFound)
+
+	checkedIn = must[models.Reservation](t)(repo.CheckInReservation(ctx, second.Id, high.Id))
+	if checkedIn.HotelRoomId != high.Id {
+		t.Fatalf("CheckInReservation room = %d, want %d", checkedIn.HotelRoomId, high.Id)
+	}
+
+	byRoom := must[models.Page[models.Reservation]](t)(repo.ListReservations(ctx, storage.ReservationFilter{HotelRoomId: low.Id})).Items
+	if len(byRoom) != 1 || byRoom[0].Id != first.Id {
+		t.Fatalf("ListReservations(room) = %+v", byRoom)
+	}
+
+	// Assigned rooms can neither be deleted nor change their type.
+	expectErr(t, repo.DeleteHotelRoom(ctx, high.Id), storage.ErrHotelRoomHasReservations)
+
+	_, err = repo.UpdateHotelRoom(ctx, high.Id, hotel.Id, otherType.Id, high.RoomNumber, high.Floor)
+	expectErr(t, err, storage.ErrHotelRoomHasReservations)
+
+	cancelled := MustCreateReservation(t, repo, roomType.Id, vis.Id, "2030-08-01", "2030-08-02")
+	must[models.Reservation](t)(repo.CancelReservation(ctx, cancelled.Id))
+
+	_, err = repo.CheckInReservation(ctx, cancelled.Id, 0)
+	expectErr(t, err, storage.ErrReservationNotCheckable)
+}
+
+func testAvailability(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	berlin := MustCreateHotel(t, repo, "Germany", "Berlin", "Berlin Central", 4)
+	budget := MustCreateHotel(t, repo, "Germany", "Berlin", "Berlin Budget", 2)
+	MustCreateHotel(t, repo, "Germany", "Hamburg", "Hamburg Harbour", 5)
+
+	single := MustCreateRoomType(t, repo, berlin.Id, 1)
+	family := MustCreateRoomType(t, repo, berlin.Id, 4)
+	suite := MustCreateRoomType(t, repo, berlin.Id, 4)
+	MustCreateHotelRoom(t, repo, MustCreateRoomType(t, repo, budget.Id, 4))
+
+	vis := MustCreateVisitor(t, repo, MustCreateHotelRoom(t, repo, single))
+	MustCreateHotelRoom(t, repo, family)
+	MustCreateHotelRoom(t, repo, family)
+	MustCreateHotelRoom(t, repo, suite)
+	MustCreateReservation(t, repo, family.Id, vis.Id, "2030-07-01", "2030-07-04")
+	MustCreateReservation(t, repo, suite.Id, vis.Id, "2030-07-01", "2030-07-05")
+
+	filter := storage.AvailabilityFilter{
+		City:     "berlin",
+		Country:  "GERMANY",
+		CheckIn:  Date(t, "2030-07-03"),
+		CheckOut: Date(t, "2030-07-06"),
+		Guests:   2,
+		StarsMin: 3,
+	}
+
+	found := must[[]models.HotelAvailability](t)(repo.SearchAvailability(ctx, filter))
+	if len(found) != 1 || found[0].Id != berlin.Id {
+		t.Fatalf("SearchAvailability = %+v, want only hotel %d", found, berlin.Id)
+	}
+	types := found[0].RoomTypes
+	if len(types) != 1 || types[0].Id != family.Id || types[0].Available != 1 {
+		t.Fatalf("SearchAvailability room types = %+v, want one free room of type %d", types, family.Id)
+	}
+
+	// After the booked stays end every room is free again.
+	filter.CheckIn, filter.CheckOut = Date(t, "2030-07-05"), Date(t, "2030-07-07")
+	found = must[[]models.HotelAvailability](t)(repo.SearchAvailability(ctx, filter))
+	if len(found) != 1 || len(found[0].RoomTypes) != 2 || found[0].RoomTypes[0].Available != 2 {
+		t.Fatalf("SearchAvailability after checkout = %+v", found)
+	}
+
+	filter.StarsMin = 1
+	found = must[[]models.HotelAvailability](t)(repo.SearchAvailability(ctx, filter))
+	if len(found) != 2 {
+		t.Fatalf("SearchAvailability with stars_min=1 = %+v", found)
+	}
+}
+
+func testPagination(t *testing.T, repo storage.Repository) {
+	ctx := context.Background()
+
+	hotels := []models.Hotel{
+		MustCreateHotel(t, repo, "Germany", "Berlin", "Adlon", 5),
+		MustCreateHotel(t, repo, "Germany", "Berlin", "Amano", 3),
+		MustCreateHotel(t, repo, "Germany", "Munich", "Bayerischer Hof", 5),
+		MustCreateHotel(t, repo, "France", "Paris", "Ritz", 5),
+		MustCreateHotel(t, repo, "France", "Paris", "Hotel 100%_Paris", 2),
+	}
+
+	// Walk every page; ties on the sort field are broken by id in the same
+	// direction, so the order is stable across pages.
+	var ids []int
+	filter := storage.HotelFilter{PageRequest: storage.PageRequest{Limit: 2, Sort: "-stars"}}
+	for pages := 0; ; pages++ {
+		if pages > len(hotels) {
+			t.Fatalf("ListHotels never ran out of pages")
+		}
+
+		page := must[models.Page[models.Hotel]](t)(repo.ListHotels(ctx, filter))
+		if len(page.Items) > 2 {
+			t.Fatalf("ListHotels returned %d items for limit 2", len(page.Items))
+		}
+		for _, h := range page.Items {
+			ids = append(ids, h.Id)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	want := []int{hotels[3].Id, hotels[2].Id, hotels[0].Id, hotels[1].Id, hotels[4].Id}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("ListHotels sorted by -stars = %v, want %v", ids, want)
+	}
+
+	tests := []struct {
+		name   string
+		filter storage.HotelFilter
+		want   []int
+	}{
+		{"country", storage.HotelFilter{Country: "france"}, []int{hotels[3].Id, hotels[4].Id}},
+		{"city", storage.HotelFilter{City: "Berlin"}, []int{hotels[0].Id, hotels[1].Id}},
+		{"stars range", storage.HotelFilter{StarsMin: 3, StarsMax: 4}, []int{hotels[1].Id}},
+		{"name prefix", storage.HotelFilter{NamePrefix: "a"}, []int{hotels[0].Id, hotels[1].Id}},
+		{"name prefix is literal", storage.HotelFilter{NamePrefix: "Hotel 100%_"}, []int{hotels[4].Id}},
+		{"name prefix wildcard", storage.HotelFilter{NamePrefix: "%"}, nil},
+		{"sort by name", storage.HotelFilter{PageRequest: storage.PageRequest{Sort: "hotel_name"}},
+			[]int{hotels[0].Id, hotels[1].Id, hotels[2].Id, hotels[4].Id, hotels[3].Id}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := must[models.Page[models.Hotel]](t)(repo.ListHotels(ctx, tt.filter))
+
+			var got []int
+			for _, h := range page.Items {
+				got = append(got, h.Id)
+			}
+			if !slices.Equal(got, tt.want) || page.NextCursor != "" {
+				t.Fatalf("ListHotels = %v (next %q), want %v", got, page.NextCursor, tt.want)
+			}
+		})
+	}
+
+	first := must[models.Page[models.Hotel]](t)(repo.ListHotels(ctx, storage.HotelFilter{PageRequest: storage.PageRequest{Limit: 1}}))
+
+	for _, page := range []storage.PageRequest{
+		{Sort: "password"},
+		{Limit: storage.MaxPageLimit + 1},
+		{Cursor: "not a cursor"},
+		{Cursor: first.NextCursor, Sort: "-id"},
+	} {
+		_, err := repo.ListHotels(ctx, storage.HotelFilter{PageRequest: page})
+		expectErr(t, err, storage.ErrValidation)
+	}
+
+	roomType := MustCreateRoomType(t, repo, hotels[0].Id, 2)
+	vis := MustCreateVisitor(t, repo, MustCreateHotelRoom(t, repo, roomType))
+	for _, dates := range [][2]string{{"2030-01-05", "2030-01-07"}, {"2030-01-01", "2030-01-03"}, {"2030-01-03", "2030-01-05"}} {
+		MustCreateReservation(t, repo, roomType.Id, vis.Id, dates[0], dates[1])
+	}
+
+	var checkIns []string
+	resFilter := storage.ReservationFilter{PageRequest: storage.PageRequest{Limit: 1}, CheckInFrom: Date(t, "2030-01-02")}
+	for {
+		page := must[models.Page[models.Reservation]](t)(repo.ListReservations(ctx, resFilter))
+		for _, r := range page.Items {
+			checkIns = append(checkIns, r.CheckIn.String())
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		resFilter.Cursor = page.NextCursor
+	}
+
+	if want := []string{"2030-01-03", "2030-01-05"}; !slices.Equal(checkIns, want) {
+		t.Fatalf("ListReservations check_in = %v, want %v", checkIns, want)
+	}
+}
+
+func MustCreateHotel(t *testing.T, repo storage.Repository, country, city, name string, stars int) models.Hotel {
+	t.Helper()
+
+	return must[models.Hotel](t)(repo.CreateHotel(context.Background(), country, city, name, stars))
+}
+
+// sequence hands out room type names and room numbers, which have to be
+// unique per hotel.
+var sequence atomic.Int64
+
+// MustCreateRoomType creates a room type for up to maxGuests adults.
+func MustCreateRoomType(t *testing.T, repo storage.Repository, hotelId int, maxGuests int) models.RoomType {
+	t.Helper()
+
+	return must[models.RoomType](t)(repo.CreateRoomType(context.Background(), models.RoomType{
+		HotelId:       hotelId,
+		Name:          fmt.Sprintf("Type %d", sequence.Add(1)),
+		MaxAdults:     maxGuests,
+		BaseOccupancy: 1,
+	}))
+}
+
+// MustCreateHotelRoom creates a room of roomType in the type's hotel.
+func MustCreateHotelRoom(t *testing.T, repo storage.Repository, roomType models.RoomType) models.HotelRoom {
+	t.Helper()
+
+	number := int(sequence.Add(1)) + 100
+	return must[models.HotelRoom](t)(repo.CreateHotelRoom(context.Background(), roomType.HotelId, roomType.Id, number, 1))
+}
+
+// MustCreateRatePlan creates a EUR plan charging basePrice minor units every
+// night.
+func MustCreateRatePlan(t *testing.T, repo storage.Repository, roomTypeId int, name string, basePrice models.Amount) models.RatePlan {
+	t.Helper()
+
+	return must[models.RatePlan](t)(repo.CreateRatePlan(context.Background(), models.RatePlan{
+		RoomTypeId: roomTypeId,
+		Name:       name,
+		Currency:   "EUR",
+		BasePrice:  basePrice,
+	}))
+}
+
+// MustCreateAmenity adds a catalog entry without labels.
+func MustCreateAmenity(t *testing.T, repo storage.Repository, code, category string) models.Amenity {
+	t.Helper()
+
+	return must[models.Amenity](t)(repo.CreateAmenity(context.Background(), models.Amenity{Code: code, Category: category}))
+}
+
+func MustCreateVisitor(t *testing.T, repo storage.Repository, room models.HotelRoom) models.Visitor {
+	t.Helper()
+
+	return must[models.Visitor](t)(repo.CreateVisitor(context.Background(), room.HotelId, room.Id, "Max", "Mustermann", 30))
+}
+
+func MustCreateReservation(t *testing.T, repo storage.Repository, roomTypeId, visitorId int, checkIn, checkOut string) models.Reservation {
+	t.Helper()
+
+	return must[models.Reservation](t)(repo.CreateReservation(context.Background(), roomTypeId, 0, visitorId, Date(t, checkIn), Date(t, checkOut), 1))
+}
+
+func Date(t *testing.T, s string) models.Date {
+	t.Helper()
+
+	d, err := models.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func amenityCodes(amenities []models.Amenity) []string {
+	codes := make([]string, 0, len(amenities))
+	for _, a := range amenities {
+		codes = append(codes, a.Code)
+	}
+	return codes
+}
+
+// must returns a function that unpacks the (value, error) pair returned by
+// the repository methods.
+func must[T any](t *testing.T) func(T, error) T {
+	return func(v T, err error) T {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return v
+	}
+}
+
+func expectErr(t *testing.T, err error, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+
+	var domainErr *storage.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind == nil {
+		t.Fatalf("error %v carries no error category", err)
+	}
+}
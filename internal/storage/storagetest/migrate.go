@@ -0,0 +1,23 @@
+package storagetest
+
+import (
+	"bookings/internal/migrations"
+	"context"
+	"testing"
+)
+
+// Migrate applies every pending migration to the database at url. Storage no
+// longer migrates on connect, so tests against a real database call it first.
+func Migrate(tb testing.TB, url string) {
+	tb.Helper()
+
+	db, err := migrations.Open(url)
+	if err != nil {
+		tb.Fatalf("open migrations db: %v", err)
+	}
+	defer db.Close()
+
+	if err := migrations.Up(context.Background(), db); err != nil {
+		tb.Fatalf("migrate: %v", err)
+	}
+}
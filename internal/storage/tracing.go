@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "bookings/internal/storage"
+
+// queryTracer emits a client span per query, named after the prepared
+// statement (create_hotel, get_hotel, ...) or "query" for ad hoc SQL such as
+// the list queries.
+type queryTracer struct {
+	tracer trace.Tracer
+}
+
+func newQueryTracer(tp trace.TracerProvider) *queryTracer {
+	return &queryTracer{tracer: tp.Tracer(tracerName)}
+}
+
+var _ pgx.QueryTracer = (*queryTracer)(nil)
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	name, attrs := "query", []attribute.KeyValue{semconv.DBSystemPostgreSQL}
+
+	if isStatementName(data.SQL) {
+		name = data.SQL
+		attrs = append(attrs, attribute.String("db.statement.name", data.SQL))
+	} else {
+		attrs = append(attrs, semconv.DBQueryText(data.SQL))
+	}
+
+	ctx, _ = t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		err := scrubbedError{data.Err}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+// isStatementName reports whether sql is the name of a prepared statement
+// rather than SQL text; the names used here are single identifiers.
+func isStatementName(sql string) bool {
+	return sql != "" && !strings.ContainsAny(sql, " \t\n")
+}
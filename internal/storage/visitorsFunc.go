@@ -3,89 +3,163 @@ package storage
 import (
 	"bookings/internal/models"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
-func (pos *Postgres) CreateVisitor(hotelId int, hotelRoom int, firstName string, lastName string, age int) error {
+func (pos *Postgres) CreateVisitor(ctx context.Context, hotelId int, hotelRoom int, firstName string, lastName string, age int) (_ models.Visitor, err error) {
 	const op = "storage.postgres.CreateVisitor"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
-	_, err := pos.conn.Exec(ctx, "create_visitor", hotelId, hotelRoom, firstName, lastName, age)
+	var id int
+	err = pos.pool.QueryRow(ctx, "create_visitor", hotelId, hotelRoom, firstName, lastName, age).Scan(&id)
 	if err != nil {
-		return fmt.Errorf("%s: exec failed: %w", op, err)
+		return models.Visitor{}, fmt.Errorf("%s: exec failed: %w", op, pos.visitorWriteErr(ctx, hotelRoom, err))
 	}
-	return nil
+
+	return pos.GetVisitor(ctx, id)
 }
 
-func (pos *Postgres) GetAllVisitors() (string, error) {
-	const op = "storage.postgres.CreateVisitor"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// VisitorFilter selects visitors for ListVisitors. Zero fields do not filter;
+// LastNamePrefix matches the start of the last name case-insensitively.
+type VisitorFilter struct {
+	PageRequest
+	HotelId        int    `form:"hotel_id" binding:"omitempty,gt=0"`
+	HotelRoomId    int    `form:"hotel_room_id" binding:"omitempty,gt=0"`
+	LastNamePrefix string `form:"last_name"`
+}
+
+// VisitorSortFields are the fields visitors can be sorted by.
+var VisitorSortFields = map[string]SortField[models.Visitor]{
+	"id":         {Column: "id", Value: func(v models.Visitor) any { return v.Id }},
+	"first_name": {Column: "first_name", Value: func(v models.Visitor) any { return v.FirstName }},
+	"last_name":  {Column: "last_name", Value: func(v models.Visitor) any { return v.LastName }},
+	"age":        {Column: "age", Value: func(v models.Visitor) any { return v.Age }},
+}
+
+func (pos *Postgres) ListVisitors(ctx context.Context, filter VisitorFilter) (_ models.Page[models.Visitor], err error) {
+	const op = "storage.postgres.ListVisitors"
+	defer observe(ctx, op, time.Now(), &err)
+
+	k, err := NewKeyset(filter.PageRequest, VisitorSortFields, "id")
+	if err != nil {
+		return models.Page[models.Visitor]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var q listQuery
+	if filter.HotelId != 0 {
+		q.filter("hotel_id = %s", filter.HotelId)
+	}
+	if filter.HotelRoomId != 0 {
+		q.filter("hotel_room_id = %s", filter.HotelRoomId)
+	}
+	if filter.LastNamePrefix != "" {
+		q.filter("lower(last_name) LIKE lower(%s)", prefixPattern(filter.LastNamePrefix))
+	}
+
+	query := build(&q, "SELECT id, hotel_id, hotel_room_id, first_name, last_name, age FROM visitors", k)
+
+	rows, err := pos.pool.Query(ctx, query, q.args...)
+	if err != nil {
+		return models.Page[models.Visitor]{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
+	}
 
-	rows, err := pos.conn.Query(ctx, "get_all_visitors")
+	visitors, err := scanVisitors(op, rows)
 	if err != nil {
-		return "", fmt.Errorf("%s: query failed: %w", op, err)
+		return models.Page[models.Visitor]{}, err
 	}
 
-	var visitors []models.Visitor
+	return k.Page(visitors), nil
+}
+
+func scanVisitors(op string, rows pgx.Rows) ([]models.Visitor, error) {
+	defer rows.Close()
+
+	visitors := []models.Visitor{}
 	for rows.Next() {
 		var vis models.Visitor
 
-		rows.Scan(&vis.Id, &vis.HotelId, &vis.HotelRoom, &vis.FirstName, &vis.LastName, &vis.Age)
-		visitors = append(visitors, vis)
+		if err := rows.Scan(&vis.Id, &vis.HotelId, &vis.HotelRoom, &vis.FirstName, &vis.LastName, &vis.Age); err != nil {
+			return nil, fmt.Errorf("%s: scan failed: %w", op, pgError(err))
+		}
 
+		visitors = append(visitors, vis)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows failed: %w", op, pgError(err))
 	}
 
-	jsonVis, _ := json.Marshal(visitors)
-	return string(jsonVis), nil
-
+	return visitors, nil
 }
 
-func (pos *Postgres) GetVisitor(id int) (string, error) {
+func (pos *Postgres) GetVisitor(ctx context.Context, id int) (_ models.Visitor, err error) {
 	const op = "storage.postgres.GetVisitor"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
 	var vis models.Visitor
 
-	err := pos.conn.QueryRow(ctx, "get_visitor", id).Scan(&vis.Id, &vis.HotelId, &vis.HotelRoom, &vis.FirstName, &vis.LastName, &vis.Age)
+	err = pos.pool.QueryRow(ctx, "get_visitor", id).Scan(&vis.Id, &vis.HotelId, &vis.HotelRoom, &vis.FirstName, &vis.LastName, &vis.Age)
 	if err != nil {
-		return "", fmt.Errorf("%s: query failed: %w", op, err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Visitor{}, fmt.Errorf("%s: %w", op, ErrVisitorNotFound)
+		}
+		return models.Visitor{}, fmt.Errorf("%s: query failed: %w", op, pgError(err))
 	}
 
-	jsonVis, _ := json.Marshal(vis)
-
-	return string(jsonVis), nil
-
+	return vis, nil
 }
 
-func (pos *Postgres) DeleteVisitor(id int) error {
+func (pos *Postgres) DeleteVisitor(ctx context.Context, id int) (err error) {
 	const op = "storage.postgres.DeleteVisitor"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
-	_, err := pos.conn.Exec(ctx, "delete_visitor", id)
+	tag, err := pos.pool.Exec(ctx, "delete_visitor", id)
 	if err != nil {
-		return fmt.Errorf("%s: exec failed: %w", op, err)
+		if pgErrCode(err) == pgForeignKeyViolation {
+			return fmt.Errorf("%s: %w", op, ErrVisitorHasReservations)
+		}
+		return fmt.Errorf("%s: exec failed: %w", op, pgError(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrVisitorNotFound)
 	}
 
 	return nil
 }
 
-func (pos *Postgres) UpdateVisitor(id int, hotelId int, hotelRoom int, firstName string, lastName string, age int) (string, error) {
+func (pos *Postgres) UpdateVisitor(ctx context.Context, id int, hotelId int, hotelRoom int, firstName string, lastName string, age int) (_ models.Visitor, err error) {
 	const op = "storage.postgres.UpdateVisitor"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	defer observe(ctx, op, time.Now(), &err)
 
-	_, err := pos.conn.Exec(ctx, "update_visitor", hotelId, hotelRoom, firstName, lastName, age, id)
+	tag, err := pos.pool.Exec(ctx, "update_visitor", hotelId, hotelRoom, firstName, lastName, age, id)
 	if err != nil {
-		return "", fmt.Errorf("%s: exec failed: %w", op, err)
+		return models.Visitor{}, fmt.Errorf("%s: exec failed: %w", op, pos.visitorWriteErr(ctx, hotelRoom, err))
 	}
 
-	getUV, _ := pos.GetVisitor(id)
+	if tag.RowsAffected() == 0 {
+		return models.Visitor{}, fmt.Errorf("%s: %w", op, ErrVisitorNotFound)
+	}
+
+	return pos.GetVisitor(ctx, id)
+}
 
-	return getUV, nil
+// visitorWriteErr translates constraint violations raised by visitor writes.
+// The composite room foreign key fails both for a missing room and for a room
+// of another hotel, so the room is looked up to tell them apart.
+func (pos *Postgres) visitorWriteErr(ctx context.Context, hotelRoom int, err error) error {
+	switch pgErrCode(err) {
+	case pgForeignKeyViolation:
+		var exists bool
+		if pos.pool.QueryRow(ctx, "hotel_room_exists", hotelRoom).Scan(&exists) == nil && exists {
+			return ErrVisitorHotelMismatch
+		}
+		return ErrHotelRoomNotFound
+	case pgCheckViolation:
+		return ErrVisitorInvalidAge
+	}
+	return pgError(err)
 }
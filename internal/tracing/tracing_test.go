@@ -0,0 +1,22 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSetup(t *testing.T) {
+	for _, exporter := range []string{ExporterNone, ExporterStdout, ExporterOTLP} {
+		shutdown, err := Setup(context.Background(), exporter, "localhost:4318")
+		if err != nil {
+			t.Fatalf("Setup(%s) failed: %v", exporter, err)
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Errorf("shutdown(%s) failed: %v", exporter, err)
+		}
+	}
+
+	if _, err := Setup(context.Background(), "zipkin", ""); err == nil {
+		t.Error("Setup(zipkin) succeeded, want error")
+	}
+}